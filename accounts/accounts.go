@@ -19,9 +19,12 @@ package accounts
 
 import (
 	"crypto/ecdsa"
+	"math/big"
 
 	gethaccounts "github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
@@ -30,6 +33,43 @@ import (
 type Account struct {
 	Address   common.Address   `json:"address"`   // Ethereum account address derived from the key
 	PublicKey *ecdsa.PublicKey `json:"publicKey"` // Public key corresponding to the account address
+	URL       gethaccounts.URL `json:"url"`       // Optional resource locator within a backend
+}
+
+// DeviceInfo contains the USB descriptor details of the device backing a hardware wallet.
+type DeviceInfo struct {
+	Path         string `json:"path"`         // Platform-specific path of the USB device
+	VendorID     uint16 `json:"vendorId"`     // USB vendor identifier
+	ProductID    uint16 `json:"productId"`    // USB product identifier
+	Release      uint16 `json:"release"`      // Device release number in binary-coded decimal
+	Manufacturer string `json:"manufacturer"` // Manufacturer string reported by the device, e.g. "Ledger"
+	Product      string `json:"product"`      // Product string reported by the device, e.g. "Nano X"
+	Serial       string `json:"serial"`       // Serial number string reported by the device, if any
+}
+
+// AppConfiguration describes the configuration of the app running on a hardware wallet.
+type AppConfiguration struct {
+	Version      [3]byte `json:"version"`      // Major, minor and patch version of the app
+	BlindSigning bool    `json:"blindSigning"` // Whether signing arbitrary data (blind signing) is enabled in the app settings
+}
+
+// AppInfo describes an app installed on a hardware wallet.
+type AppInfo struct {
+	Name    string `json:"name"`           // Name of the app, e.g. "Ethereum"
+	Version string `json:"version"`        // Version of the app, e.g. "1.10.2"
+	Hash    []byte `json:"hash,omitempty"` // Hash of the app binary, if reported by the wallet
+}
+
+// TokenInfo describes an ERC-20 token, so that hardware wallets can display the
+// amounts of a signing request in units of the token (e.g. "100 USDC") instead of
+// raw values. The signature is issued by the wallet vendor (for Ledger, the
+// Crypto Asset List) and devices reject descriptors that are not signed by it.
+type TokenInfo struct {
+	Ticker    string         `json:"ticker"`    // Ticker of the token, e.g. "USDC"
+	Address   common.Address `json:"address"`   // Address of the token contract
+	Decimals  uint32         `json:"decimals"`  // Number of decimals of the token amounts
+	ChainID   uint32         `json:"chainId"`   // EIP-155 chain identifier of the token contract
+	Signature []byte         `json:"signature"` // Vendor signature of the token descriptor
 }
 
 // Wallet represents a software or hardware wallet that might contain one or more
@@ -40,11 +80,31 @@ type Wallet interface {
 	// backends.
 	URL() gethaccounts.URL
 
+	// Info returns the USB descriptor details of the device backing the wallet.
+	Info() DeviceInfo
+
 	// Status returns a textual status to aid the user in the current state of the
 	// wallet. It also returns an error indicating any failure the wallet might have
 	// encountered.
 	Status() (string, error)
 
+	// AppConfiguration queries the hardware wallet for the configuration of the app
+	// currently running on it.
+	AppConfiguration() (AppConfiguration, error)
+
+	// RunningApp queries the hardware wallet for the name and version of the app
+	// currently running on it.
+	RunningApp() (AppInfo, error)
+
+	// OpenApp requests the hardware wallet to launch the app with the given name
+	// (e.g. "Ethereum"), which the user must confirm on the device. It only succeeds
+	// while no app is running.
+	OpenApp(name string) error
+
+	// ListApps queries the hardware wallet for the apps installed on it. It only
+	// succeeds while no app is running.
+	ListApps() ([]AppInfo, error)
+
 	// Open initializes access to a wallet instance. It is not meant to unlock or
 	// decrypt account keys, rather simply to establish a connection to hardware
 	// wallets and/or to access derivation seeds.
@@ -74,8 +134,41 @@ type Wallet interface {
 	// to the wallet's tracked account list.
 	Derive(path gethaccounts.DerivationPath, pin bool) (Account, error)
 
+	// DeriveWithDisplay behaves like Derive, but additionally shows the derived
+	// address on the hardware wallet and waits for the user to confirm it.
+	DeriveWithDisplay(path gethaccounts.DerivationPath, pin bool) (Account, error)
+
+	// DeriveWithChainCode derives the hierarchical deterministic account at the
+	// specified derivation path, along with the BIP-32 chain code of its node, which
+	// allows deriving the public keys of its children without the hardware wallet.
+	// The derived account is not added to the wallet's tracked account list.
+	DeriveWithChainCode(path gethaccounts.DerivationPath) (Account, []byte, error)
+
 	// SignTypedData signs a TypedData object using EIP-712 encoding
 	SignTypedData(account Account, typedData apitypes.TypedData) ([]byte, error)
+
+	// SignTypedDataWithTokens behaves like SignTypedData, but first provides the
+	// given token descriptors to the hardware wallet, so that it can display the
+	// token amounts of the message in a human-readable form.
+	SignTypedDataWithTokens(account Account, typedData apitypes.TypedData, tokens []TokenInfo) ([]byte, error)
+
+	// SignTypedDataFull behaves like SignTypedDataWithTokens, but sends the whole
+	// typed data to the hardware wallet, so that it can display the fields of the
+	// message instead of its hashes.
+	SignTypedDataFull(account Account, typedData apitypes.TypedData, tokens []TokenInfo) ([]byte, error)
+
+	// SignTx requests the wallet to sign the given transaction. It returns the signed
+	// transaction, with its V, R and S values set. A nil chain ID requests a legacy
+	// (Homestead) signature, which typed transactions don't support.
+	SignTx(account Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+
+	// SignText requests the wallet to sign the hash of a given piece of data, prefixed
+	// by the Ethereum prefix scheme (EIP-191 personal_sign):
+	//
+	//	keccak256("\x19Ethereum Signed Message:\n" + len(text) + text)
+	//
+	// The signature is returned in the 65-byte [R || S || V] format.
+	SignText(account Account, text []byte) ([]byte, error)
 }
 
 // Backend is a "wallet provider" that may contain a batch of accounts they can
@@ -92,4 +185,27 @@ type Backend interface {
 	// go, the same wallet might appear at a different positions in the list during
 	// subsequent retrievals.
 	Wallets() []Wallet
+
+	// Subscribe creates an async subscription to receive notifications when the
+	// backend detects the arrival or departure of a wallet.
+	Subscribe(sink chan<- WalletEvent) event.Subscription
+}
+
+// WalletEventType represents the different event types that can be fired by
+// the wallet subscription subsystem.
+type WalletEventType int
+
+const (
+	// WalletArrived is fired when a new wallet is detected via USB.
+	WalletArrived WalletEventType = iota
+
+	// WalletDropped is fired when a wallet is removed or fails.
+	WalletDropped
+)
+
+// WalletEvent is an event fired by an account backend when a wallet arrival or
+// departure is detected.
+type WalletEvent struct {
+	Wallet Wallet          // Wallet instance arrived or departed
+	Kind   WalletEventType // Event type that happened in the system
 }
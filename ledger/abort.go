@@ -0,0 +1,47 @@
+package ledger
+
+import "context"
+
+// AbortCurrentOperation aborts the signing requests in progress, such as a
+// SignSECP256K1 awaiting the user confirmation, which return ErrAborted right away.
+// It is meant to be called from another goroutine, e.g. when the user cancels the
+// operation in the UI, and does nothing if no request is in progress. Every signing
+// method can be aborted: SignSECP256K1 and its variants, SignTypedData,
+// SignPersonalMessage, SignTx and SignBatch. Derivations and the other requests
+// cannot.
+//
+// The prompt stays on the device until the user answers it or the device is
+// unplugged, and the next requests wait for the device to reply before being sent,
+// since the device cannot process a new command while a prompt is pending.
+func (e *EvmosSECP256K1) AbortCurrentOperation() {
+	e.abortMu.Lock()
+	defer e.abortMu.Unlock()
+
+	for id, cancel := range e.aborts {
+		cancel(ErrAborted)
+		delete(e.aborts, id)
+	}
+}
+
+// abortable returns a context derived from ctx which is cancelled with ErrAborted by
+// AbortCurrentOperation, and the function releasing it once the request completes.
+func (e *EvmosSECP256K1) abortable(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	e.abortMu.Lock()
+	if e.aborts == nil {
+		e.aborts = make(map[uint64]context.CancelCauseFunc)
+	}
+	id := e.nextAbortID
+	e.nextAbortID++
+	e.aborts[id] = cancel
+	e.abortMu.Unlock()
+
+	return ctx, func() {
+		e.abortMu.Lock()
+		delete(e.aborts, id)
+		e.abortMu.Unlock()
+
+		cancel(context.Canceled)
+	}
+}
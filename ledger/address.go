@@ -0,0 +1,135 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos/v14/crypto/ethsecp256k1"
+)
+
+// FormatBech32 encodes an account address with the given "Human Readable Prefix"
+// (e.g. "evmos1..."). The input is either the 20-byte address, or the 33-byte
+// compressed or 65-byte uncompressed secp256k1 public key the address is derived
+// from. It doesn't communicate with the device, and doesn't depend on the global
+// Cosmos SDK config.
+func FormatBech32(pubOrAddrBytes []byte, hrp string) (string, error) {
+	address, err := toAddress(pubOrAddrBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return encodeBech32(hrp, address.Bytes())
+}
+
+// FormatHexAddress encodes an account address as an EIP-55 checksummed hex string
+// (e.g. "0xAbC..."). It accepts the same inputs as FormatBech32 and doesn't
+// communicate with the device.
+func FormatHexAddress(pubOrAddrBytes []byte) (string, error) {
+	address, err := toAddress(pubOrAddrBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return address.Hex(), nil
+}
+
+// PubKeyToAddresses returns both address forms of the account of the given 33-byte
+// compressed or 65-byte uncompressed secp256k1 public key, e.g. as returned by
+// GetPublicKeySECP256K1: the EIP-55 checksummed hex Ethereum address and the bech32
+// address encoded with the given "Human Readable Prefix". It doesn't communicate
+// with the device.
+func PubKeyToAddresses(pubKeyBytes []byte, hrp string) (hex string, bech32 string, err error) {
+	if len(pubKeyBytes) == common.AddressLength {
+		return "", "", fmt.Errorf("invalid public key length: %d", len(pubKeyBytes))
+	}
+
+	address, err := toAddress(pubKeyBytes)
+	if err != nil {
+		return "", "", err
+	}
+
+	bech32Addr, err := encodeBech32(hrp, address.Bytes())
+	if err != nil {
+		return "", "", err
+	}
+
+	return address.Hex(), bech32Addr, nil
+}
+
+// ToCosmosPubKey converts the 33-byte compressed or 65-byte uncompressed secp256k1
+// public key returned by GetPublicKeySECP256K1 into the Ethereum secp256k1 public
+// key type of the Cosmos SDK (i.e. ethsecp256k1), e.g. to build a keyring record of
+// the Ledger account. It doesn't communicate with the device.
+func ToCosmosPubKey(pubKeyBytes []byte) (cryptotypes.PubKey, error) {
+	switch len(pubKeyBytes) {
+	case 33:
+		if _, err := crypto.DecompressPubkey(pubKeyBytes); err != nil {
+			return nil, fmt.Errorf("invalid compressed public key: %w", err)
+		}
+		return &ethsecp256k1.PubKey{Key: pubKeyBytes}, nil
+	case 65:
+		pubKey, err := crypto.UnmarshalPubkey(pubKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uncompressed public key: %w", err)
+		}
+		return &ethsecp256k1.PubKey{Key: crypto.CompressPubkey(pubKey)}, nil
+	default:
+		return nil, fmt.Errorf("invalid public key length: %d", len(pubKeyBytes))
+	}
+}
+
+// encodeBech32 encodes the address bytes with the given "Human Readable Prefix",
+// without relying on the global Cosmos SDK config.
+func encodeBech32(hrp string, address []byte) (string, error) {
+	if hrp == "" {
+		return "", errors.New("prefix cannot be empty")
+	}
+
+	return bech32.ConvertAndEncode(hrp, address)
+}
+
+// decodeBech32 decodes the bech32 address and checks that it is encoded with the
+// given "Human Readable Prefix", without relying on the global Cosmos SDK config.
+func decodeBech32(address string, hrp string) ([]byte, error) {
+	if address == "" {
+		return nil, errors.New("decoding Bech32 address failed: must provide a non empty address")
+	}
+
+	prefix, bz, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if prefix != hrp {
+		return nil, fmt.Errorf("invalid Bech32 prefix; expected %s, got %s", hrp, prefix)
+	}
+
+	return bz, nil
+}
+
+// toAddress returns the address given as is or derived from the public key.
+func toAddress(pubOrAddrBytes []byte) (common.Address, error) {
+	switch len(pubOrAddrBytes) {
+	case common.AddressLength:
+		return common.BytesToAddress(pubOrAddrBytes), nil
+	case 33:
+		pubKey, err := crypto.DecompressPubkey(pubOrAddrBytes)
+		if err != nil {
+			return common.Address{}, fmt.Errorf("invalid compressed public key: %w", err)
+		}
+		return crypto.PubkeyToAddress(*pubKey), nil
+	case 65:
+		pubKey, err := crypto.UnmarshalPubkey(pubOrAddrBytes)
+		if err != nil {
+			return common.Address{}, fmt.Errorf("invalid uncompressed public key: %w", err)
+		}
+		return crypto.PubkeyToAddress(*pubKey), nil
+	default:
+		return common.Address{}, fmt.Errorf("invalid address or public key length: %d", len(pubOrAddrBytes))
+	}
+}
@@ -0,0 +1,136 @@
+package ledger_test
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos/v14/crypto/ethsecp256k1"
+)
+
+func (suite *LedgerTestSuite) TestFormatAddress() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	// Leading zero bytes must be preserved in both encodings
+	zeroAddr := common.BytesToAddress(append(bytes.Repeat([]byte{0}, 19), 0x01))
+
+	testCases := []struct {
+		name    string
+		input   []byte
+		expAddr common.Address
+		expPass bool
+	}{
+		{"pass - address", addr.Bytes(), addr, true},
+		{"pass - address with leading zeros", zeroAddr.Bytes(), zeroAddr, true},
+		{"pass - uncompressed public key", crypto.FromECDSAPub(&privKey.PublicKey), addr, true},
+		{"pass - compressed public key", crypto.CompressPubkey(&privKey.PublicKey), addr, true},
+		{"fail - empty input", nil, common.Address{}, false},
+		{"fail - invalid length", bytes.Repeat([]byte{1}, 32), common.Address{}, false},
+		{"fail - invalid compressed public key", append([]byte{0x05}, bytes.Repeat([]byte{1}, 32)...), common.Address{}, false},
+		{"fail - invalid uncompressed public key", append([]byte{0x04}, bytes.Repeat([]byte{0}, 64)...), common.Address{}, false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			bech32Addr, bech32Err := ledger.FormatBech32(tc.input, "evmos")
+			hexAddr, hexErr := ledger.FormatHexAddress(tc.input)
+			if !tc.expPass {
+				suite.Require().Error(bech32Err)
+				suite.Require().Error(hexErr)
+				return
+			}
+
+			suite.Require().NoError(bech32Err)
+			decoded, err := sdk.GetFromBech32(bech32Addr, "evmos")
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expAddr.Bytes(), decoded)
+
+			suite.Require().NoError(hexErr)
+			suite.Require().Equal(tc.expAddr.Hex(), hexAddr)
+			suite.Require().True(common.IsHexAddress(hexAddr))
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestFormatHexAddressChecksum() {
+	// EIP-55 test vector
+	addr := common.HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+
+	hexAddr, err := ledger.FormatHexAddress(addr.Bytes())
+	suite.Require().NoError(err)
+	suite.Require().Equal("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", hexAddr)
+}
+
+func (suite *LedgerTestSuite) TestPubKeyToAddresses() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	testCases := []struct {
+		name    string
+		pubKey  []byte
+		hrp     string
+		expPass bool
+	}{
+		{"pass - uncompressed public key", crypto.FromECDSAPub(&privKey.PublicKey), "evmos", true},
+		{"pass - compressed public key", crypto.CompressPubkey(&privKey.PublicKey), "cosmos", true},
+		{"fail - address instead of public key", addr.Bytes(), "evmos", false},
+		{"fail - invalid public key", append([]byte{0x04}, bytes.Repeat([]byte{0}, 64)...), "evmos", false},
+		{"fail - empty HRP", crypto.FromECDSAPub(&privKey.PublicKey), "", false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			hexAddr, bech32Addr, err := ledger.PubKeyToAddresses(tc.pubKey, tc.hrp)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(addr.Hex(), hexAddr)
+
+			decoded, err := sdk.GetFromBech32(bech32Addr, tc.hrp)
+			suite.Require().NoError(err)
+			suite.Require().Equal(addr.Bytes(), decoded)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestToCosmosPubKey() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	testCases := []struct {
+		name    string
+		pubKey  []byte
+		expPass bool
+	}{
+		{"pass - uncompressed public key", crypto.FromECDSAPub(&privKey.PublicKey), true},
+		{"pass - compressed public key", crypto.CompressPubkey(&privKey.PublicKey), true},
+		{"fail - address", addr.Bytes(), false},
+		{"fail - invalid compressed public key", append([]byte{0x05}, bytes.Repeat([]byte{1}, 32)...), false},
+		{"fail - invalid uncompressed public key", append([]byte{0x04}, bytes.Repeat([]byte{0}, 64)...), false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			pubKey, err := ledger.ToCosmosPubKey(tc.pubKey)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(ethsecp256k1.KeyType, pubKey.Type())
+			suite.Require().Equal(crypto.CompressPubkey(&privKey.PublicKey), pubKey.Bytes())
+			suite.Require().Equal(addr.Bytes(), pubKey.Address().Bytes())
+		})
+	}
+}
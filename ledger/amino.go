@@ -0,0 +1,83 @@
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// aminoSignDoc is the Amino JSON encoding of legacytx.StdSignDoc, in which the
+// integers are encoded as strings.
+type aminoSignDoc struct {
+	AccountNumber string            `json:"account_number"`
+	Sequence      string            `json:"sequence"`
+	TimeoutHeight string            `json:"timeout_height,omitempty"`
+	ChainID       string            `json:"chain_id"`
+	Memo          string            `json:"memo"`
+	Fee           json.RawMessage   `json:"fee"`
+	Msgs          []json.RawMessage `json:"msgs"`
+	Tip           json.RawMessage   `json:"tip,omitempty"`
+}
+
+// SignAminoJSON signs the legacy Amino JSON sign bytes of a transaction (i.e. the
+// ones produced by legacytx.StdSignBytes), for messages that don't support the
+// protobuf sign mode. ErrInvalidSignDoc is returned if the sign bytes are not a
+// valid Amino JSON sign doc.
+//
+// The Ethereum app cannot sign the raw Amino JSON hash, so the sign doc is signed
+// as EIP-712 typed data, like with SignSECP256K1, which Evmos chains verify for
+// legacy Amino transactions. The signature is returned in the same format.
+func (e *EvmosSECP256K1) SignAminoJSON(hdPath []uint32, signBytes []byte) ([]byte, error) {
+	if err := validateAminoSignDoc(signBytes); err != nil {
+		return nil, err
+	}
+
+	return e.SignSECP256K1(hdPath, signBytes)
+}
+
+// validateAminoSignDoc checks that the sign bytes are a single Amino JSON sign doc
+// with the fields of legacytx.StdSignDoc, at least one message and a fee.
+func validateAminoSignDoc(signBytes []byte) error {
+	if err := validateSignDoc(signBytes); err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(signBytes))
+	decoder.DisallowUnknownFields()
+
+	var signDoc aminoSignDoc
+	if err := decoder.Decode(&signDoc); err != nil {
+		return fmt.Errorf("%w: not an Amino JSON sign doc: %w", ErrInvalidSignDoc, err)
+	}
+
+	if decoder.More() {
+		return fmt.Errorf("%w: unexpected data after the Amino JSON sign doc", ErrInvalidSignDoc)
+	}
+
+	for name, value := range map[string]string{
+		"account_number": signDoc.AccountNumber,
+		"sequence":       signDoc.Sequence,
+	} {
+		if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+			return fmt.Errorf("%w: invalid %s %q", ErrInvalidSignDoc, name, value)
+		}
+	}
+
+	if signDoc.TimeoutHeight != "" {
+		if _, err := strconv.ParseUint(signDoc.TimeoutHeight, 10, 64); err != nil {
+			return fmt.Errorf("%w: invalid timeout_height %q", ErrInvalidSignDoc, signDoc.TimeoutHeight)
+		}
+	}
+
+	switch {
+	case signDoc.ChainID == "":
+		return fmt.Errorf("%w: missing chain_id", ErrInvalidSignDoc)
+	case len(signDoc.Fee) == 0 || bytes.Equal(signDoc.Fee, []byte("null")):
+		return fmt.Errorf("%w: missing fee", ErrInvalidSignDoc)
+	case len(signDoc.Msgs) == 0:
+		return fmt.Errorf("%w: no messages", ErrInvalidSignDoc)
+	default:
+		return nil
+	}
+}
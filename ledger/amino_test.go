@@ -0,0 +1,104 @@
+package ledger_test
+
+import (
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestSignAminoJSON() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	testCases := []struct {
+		name     string
+		signDoc  func() []byte
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - protobuf sign doc",
+			func() []byte { return suite.txProtobuf },
+			func() {},
+			false,
+		},
+		{
+			"fail - unknown field",
+			func() []byte {
+				return []byte(`{"account_number":"0","chain_id":"evmos_9000-1","fee":{"amount":[],"gas":"0"},"memo":"","msgs":[{}],"sequence":"0","extra":true}`)
+			},
+			func() {},
+			false,
+		},
+		{
+			"fail - non-numeric account number",
+			func() []byte {
+				return []byte(`{"account_number":"zero","chain_id":"evmos_9000-1","fee":{"amount":[],"gas":"0"},"memo":"","msgs":[{}],"sequence":"0"}`)
+			},
+			func() {},
+			false,
+		},
+		{
+			"fail - missing chain ID",
+			func() []byte {
+				return []byte(`{"account_number":"0","fee":{"amount":[],"gas":"0"},"memo":"","msgs":[{}],"sequence":"0"}`)
+			},
+			func() {},
+			false,
+		},
+		{
+			"fail - missing fee",
+			func() []byte {
+				return []byte(`{"account_number":"0","chain_id":"evmos_9000-1","memo":"","msgs":[{}],"sequence":"0"}`)
+			},
+			func() {},
+			false,
+		},
+		{
+			"fail - no messages",
+			func() []byte {
+				return []byte(`{"account_number":"0","chain_id":"evmos_9000-1","fee":{"amount":[],"gas":"0"},"memo":"","msgs":[],"sequence":"0"}`)
+			},
+			func() {},
+			false,
+		},
+		{
+			"fail - trailing data",
+			func() []byte { return append(append([]byte{}, suite.txAmino...), suite.txAmino...) },
+			func() {},
+			false,
+		},
+		{
+			"pass - Amino JSON sign doc signed",
+			func() []byte { return suite.txAmino },
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			signature, err := suite.ledger.SignAminoJSON(gethaccounts.DefaultBaseDerivationPath, tc.signDoc())
+			if !tc.expPass {
+				suite.Require().ErrorIs(err, ledger.ErrInvalidSignDoc)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Len(signature, crypto.SignatureLength)
+		})
+	}
+}
@@ -0,0 +1,386 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/usbwallet"
+)
+
+const (
+	// ethereumAppName is the name reported by the Ledger Ethereum app.
+	ethereumAppName = "Ethereum"
+
+	// dashboardAppName is the name reported by the Ledger OS when no app is running.
+	dashboardAppName = "BOLOS"
+
+	// appLaunchAttempts is the number of times the device is detected again after
+	// launching the Ethereum app, until it reconnects with the app running.
+	appLaunchAttempts = 20
+
+	// appLaunchDelay is the time between the detections of the device after
+	// launching the Ethereum app.
+	appLaunchDelay = 250 * time.Millisecond
+)
+
+// DefaultEIP712FullDisplayVersion is the first version of the Ledger Ethereum app
+// displaying the fields of EIP-712 messages, instead of only their hashes.
+var DefaultEIP712FullDisplayVersion = [3]uint8{1, 9, 19}
+
+// GetAppVersion queries the version of the Ethereum app running on the Ledger. It
+// allows callers to check whether features such as the EIP-712 full display are
+// supported by the installed app.
+func (e *EvmosSECP256K1) GetAppVersion() (major, minor, patch uint8, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return 0, 0, 0, errors.New("could not get Ledger app version: no wallet found")
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return 0, 0, 0, err
+	}
+
+	config, err := e.PrimaryWallet.AppConfiguration()
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("unable to get Ledger app version, please open the Ethereum app and retry: %w", err)
+	}
+
+	return config.Version[0], config.Version[1], config.Version[2], nil
+}
+
+// SetEIP712FullDisplayVersion overrides the minimum version of the Ethereum app
+// considered by SupportsEIP712FullDisplay to display the fields of EIP-712 messages.
+// It defaults to DefaultEIP712FullDisplayVersion.
+func (e *EvmosSECP256K1) SetEIP712FullDisplayVersion(major, minor, patch uint8) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.fullDisplayVersion = &[3]byte{major, minor, patch}
+}
+
+// SupportsEIP712FullDisplay reports whether the Ethereum app running on the Ledger
+// displays the fields of EIP-712 messages. Older versions only display the domain
+// and message hashes, in which case callers may encourage users to update the app,
+// or enable SetDisplayEIP712Hashes so that the hashes can be compared.
+func (e *EvmosSECP256K1) SupportsEIP712FullDisplay() (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return false, errors.New("could not get Ledger app version: no wallet found")
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return false, err
+	}
+
+	return e.supportsEIP712FullDisplay()
+}
+
+// supportsEIP712FullDisplay reports whether the running Ethereum app displays the
+// fields of EIP-712 messages.
+//
+// Note, supportsEIP712FullDisplay assumes the lock is held!
+func (e *EvmosSECP256K1) supportsEIP712FullDisplay() (bool, error) {
+	config, err := e.PrimaryWallet.AppConfiguration()
+	if err != nil {
+		return false, fmt.Errorf("unable to get Ledger app version, please open the Ethereum app and retry: %w", err)
+	}
+
+	minVersion := DefaultEIP712FullDisplayVersion
+	if e.fullDisplayVersion != nil {
+		minVersion = *e.fullDisplayVersion
+	}
+
+	return compareVersions(config.Version, minVersion) >= 0, nil
+}
+
+// BlindSigningEnabled reports whether blind signing, i.e. signing data the Ledger
+// cannot display such as EIP-712 hashes or contract calls, is enabled in the
+// settings of the Ethereum app. Blind signing requests fail with
+// ErrBlindSigningDisabled otherwise, so callers can check it beforehand and ask the
+// user to enable it.
+func (e *EvmosSECP256K1) BlindSigningEnabled() (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return false, errors.New("could not get Ledger app configuration: no wallet found")
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return false, err
+	}
+
+	config, err := e.PrimaryWallet.AppConfiguration()
+	if err != nil {
+		return false, fmt.Errorf("unable to get Ledger app configuration, please open the Ethereum app and retry: %w", err)
+	}
+
+	return config.BlindSigning, nil
+}
+
+// blindSigningError returns ErrBlindSigningDisabled along with the signing error if
+// the app rejected the request data because blind signing is disabled, or the
+// signing error as is otherwise.
+//
+// Note, blindSigningError assumes the lock is held!
+func (e *EvmosSECP256K1) blindSigningError(err error) error {
+	if !errors.Is(err, ErrInvalidData) {
+		return err
+	}
+
+	// The status word doesn't tell why the data was rejected, so check the setting
+	config, configErr := e.PrimaryWallet.AppConfiguration()
+	if configErr != nil || config.BlindSigning {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", ErrBlindSigningDisabled, err)
+}
+
+// compareVersions returns -1, 0 or 1 if the version a is respectively lower than,
+// equal to or greater than the version b.
+func compareVersions(a, b [3]byte) int {
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// VerifyEthereumApp checks that the app currently open on the Ledger is the Ethereum
+// app. It returns ErrAppNotOpen if the device is on the dashboard, ErrWrongApp if
+// another app (e.g. Bitcoin) is open and ErrDeviceLocked if the device is locked.
+func (e *EvmosSECP256K1) VerifyEthereumApp() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return errors.New("could not verify Ledger app: no wallet found")
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return err
+	}
+
+	return verifyEthereumApp(e.PrimaryWallet)
+}
+
+// IsReady reports whether the Ledger is ready to sign: a device is connected, it is
+// unlocked and the Ethereum app is open, e.g. to enable signing in a UI with a single
+// call. If it isn't, the reason is returned as an error: ErrNoDevice if no device is
+// connected, including if it was unplugged since connecting, ErrDeviceBusy if it is
+// held by another application, ErrDeviceLocked if it is locked, and ErrAppNotOpen or
+// ErrWrongApp if the Ethereum app is not open.
+// No request requiring user interaction is sent.
+func (e *EvmosSECP256K1) IsReady() (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil || !e.primaryWalletConnected() {
+		return false, fmt.Errorf("could not check Ledger readiness: %w", ErrNoDevice)
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return false, err
+	}
+
+	if err := verifyEthereumApp(e.PrimaryWallet); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// primaryWalletConnected reports whether the hub still lists the primary wallet,
+// i.e. whether its device is still plugged in. The devices are scanned right away,
+// since the periodic refresh may not have noticed yet that the device was unplugged.
+// The primary wallet is assumed connected if there is no hub, e.g. if it was set by
+// the embedder.
+//
+// Note, primaryWalletConnected assumes the lock is held!
+func (e *EvmosSECP256K1) primaryWalletConnected() bool {
+	if e.Hub == nil {
+		return true
+	}
+
+	//#nosec G703 -- the wallets of the last scan are used if the devices cannot be scanned
+	_ = e.Hub.RefreshDevices()
+
+	url := e.PrimaryWallet.URL()
+	for _, wallet := range e.Hub.Wallets() {
+		if wallet.URL().Cmp(url) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestOpenApp asks the Ledger to launch the app with the given name (e.g.
+// "Ethereum" or "Cosmos"), instead of requiring the user to navigate to it. The
+// user must confirm opening the app on the device. Nothing is requested if the app
+// is already running, while ErrWrongApp is returned if another app is running,
+// since apps can only be launched from the dashboard. ErrAppNotInstalled is
+// returned if the app is not installed, and ErrUserRejected if the user declines.
+// Opening an app makes the device reconnect, so Reconnect should be called before
+// sending further requests.
+func (e *EvmosSECP256K1) RequestOpenApp(name string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return errors.New("could not open Ledger app: no wallet found")
+	}
+
+	if name == "" {
+		return errors.New("could not open Ledger app: empty app name")
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return err
+	}
+
+	app, err := e.PrimaryWallet.RunningApp()
+	switch {
+	case errors.Is(err, ErrDeviceLocked):
+		return fmt.Errorf("unable to open Ledger app: %w", err)
+	case err != nil:
+		// Older firmware versions don't support querying the running app, in which
+		// case the request is sent anyway and fails if the dashboard is not displayed
+	case app.Name == name:
+		return nil
+	case app.Name != dashboardAppName:
+		return fmt.Errorf("%w (found %s app), please close it to open the %s app", ErrWrongApp, app.Name, name)
+	}
+
+	e.prompt("Please confirm opening the %s app on your Ledger...", name)
+
+	if err := e.PrimaryWallet.OpenApp(name); err != nil {
+		return fmt.Errorf("unable to open the %s app on Ledger: %w", name, err)
+	}
+
+	return nil
+}
+
+// launchEthereumApp asks the wallet, which is on the dashboard, to launch the Ethereum
+// app, and connects to the device again once it reconnects with the app running.
+// ErrAppNotOpen is returned if the app could not be launched, e.g. because the user
+// declined, or if the device doesn't report the app running in time.
+//
+// Note, launchEthereumApp assumes the lock is held!
+func (e *EvmosSECP256K1) launchEthereumApp(ctx context.Context, wallet accounts.Wallet) error {
+	if err := e.openWallet(ctx, wallet); err != nil {
+		return fmt.Errorf("%w: unable to open Ledger: %w", ErrAppNotOpen, err)
+	}
+
+	e.prompt("Please confirm opening the %s app on your Ledger...", ethereumAppName)
+
+	err := wallet.OpenApp(ethereumAppName)
+	//#nosec G703 -- the device reconnects once the app is launched, so the wallet is not usable anymore
+	_ = wallet.Close()
+	if err != nil {
+		return fmt.Errorf("%w: unable to open the %s app on Ledger: %w", ErrAppNotOpen, ethereumAppName, err)
+	}
+
+	// Opening an app makes the device reconnect, which takes a moment
+	preferredURL := wallet.URL().String()
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(appLaunchDelay):
+		}
+
+		err = e.connectWallet(ctx, preferredURL, false)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		if attempt >= appLaunchAttempts {
+			return fmt.Errorf("%w: the %s app didn't start on Ledger: %w", ErrAppNotOpen, ethereumAppName, err)
+		}
+
+		e.log().Debugf("Waiting for the %s app to start on Ledger (attempt %d/%d): %v",
+			ethereumAppName, attempt+1, appLaunchAttempts, err)
+	}
+}
+
+// ListInstalledApps queries the Ledger dashboard for the apps installed on the
+// device, e.g. to diagnose whether the Ethereum app is installed at all. Since apps
+// can only be listed from the dashboard, ErrWrongApp is returned if an app is
+// running. The dashboard doesn't report the versions of the apps, so only their
+// names and hashes are set. Depending on the firmware, the device may refuse to
+// disclose its apps outside of Ledger Live, in which case an error is returned.
+func (e *EvmosSECP256K1) ListInstalledApps() ([]accounts.AppInfo, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return nil, errors.New("could not list Ledger apps: no wallet found")
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return nil, err
+	}
+
+	app, err := e.PrimaryWallet.RunningApp()
+	switch {
+	case errors.Is(err, ErrDeviceLocked):
+		return nil, fmt.Errorf("unable to list Ledger apps: %w", err)
+	case err != nil:
+		// Older firmware versions don't support querying the running app, in which
+		// case the request is sent anyway and fails if the dashboard is not displayed
+	case app.Name != dashboardAppName:
+		return nil, fmt.Errorf("%w (found %s app), please close it to list the installed apps", ErrWrongApp, app.Name)
+	}
+
+	apps, err := e.PrimaryWallet.ListApps()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list Ledger apps: %w", err)
+	}
+
+	return apps, nil
+}
+
+// verifyEthereumApp checks that the app currently open on the wallet is the Ethereum app.
+func verifyEthereumApp(wallet accounts.Wallet) error {
+	app, err := wallet.RunningApp()
+
+	var apduErr *usbwallet.APDUError
+	switch {
+	case errors.Is(err, ErrDeviceLocked):
+		// The device enumerates while locked, but refuses every request until unlocked
+		return fmt.Errorf("unable to verify Ledger app: %w", err)
+	case errors.As(err, &apduErr):
+		// Older firmware versions don't support querying the running app. Fall back to
+		// requesting the app configuration, which only succeeds on the Ethereum app.
+		if _, err := wallet.AppConfiguration(); err != nil {
+			return fmt.Errorf("unable to verify Ledger app: %w", err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("unable to verify Ledger app: %w", err)
+	case app.Name == dashboardAppName:
+		return ErrAppNotOpen
+	case app.Name != ethereumAppName:
+		return fmt.Errorf("%w (found %s app)", ErrWrongApp, app.Name)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,683 @@
+package ledger_test
+
+import (
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos-ledger-go/usbwallet"
+)
+
+func (suite *LedgerTestSuite) TestGetAppVersion() {
+	testCases := []struct {
+		name       string
+		mockFunc   func()
+		expVersion [3]uint8
+		expErr     error
+		expPass    bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			[3]uint8{},
+			nil,
+			false,
+		},
+		{
+			"fail - Ethereum app not open",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfigurationError(suite.mockWallet, 0x6d00)
+			},
+			[3]uint8{},
+			ledger.ErrAppNotOpen,
+			false,
+		},
+		{
+			"pass - get app version",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 10, 2}})
+			},
+			[3]uint8{1, 10, 2},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			major, minor, patch, err := suite.ledger.GetAppVersion()
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expVersion, [3]uint8{major, minor, patch})
+			} else {
+				suite.Require().Error(err)
+				if tc.expErr != nil {
+					suite.Require().ErrorIs(err, tc.expErr)
+				}
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestVerifyEthereumApp() {
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expErr   error
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			nil,
+			false,
+		},
+		{
+			"fail - device on the dashboard",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "BOLOS")
+			},
+			ledger.ErrAppNotOpen,
+			false,
+		},
+		{
+			"fail - another app is open",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "Bitcoin")
+			},
+			ledger.ErrWrongApp,
+			false,
+		},
+		{
+			"fail - device locked",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningAppError(suite.mockWallet, 0x5515)
+			},
+			ledger.ErrDeviceLocked,
+			false,
+		},
+		{
+			"fail - older firmware without the Ethereum app open",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningAppError(suite.mockWallet, 0x6e00)
+				RegisterAppConfigurationError(suite.mockWallet, 0x6d00)
+			},
+			ledger.ErrAppNotOpen,
+			false,
+		},
+		{
+			"pass - older firmware with the Ethereum app open",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningAppError(suite.mockWallet, 0x6e00)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 10, 2}})
+			},
+			nil,
+			true,
+		},
+		{
+			"pass - Ethereum app is open",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "Ethereum")
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			err := suite.ledger.VerifyEthereumApp()
+			if tc.expPass {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().Error(err)
+				if tc.expErr != nil {
+					suite.Require().ErrorIs(err, tc.expErr)
+				}
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestIsReady() {
+	device := usbwallet.DeviceInfo{Path: "tcp://127.0.0.1:9999", ProductID: 0x4015, UsagePage: 0xffa0}
+
+	// newHub returns a hub listing the given devices
+	newHub := func(devices ...usbwallet.DeviceInfo) *usbwallet.Hub {
+		hub, err := usbwallet.NewLedgerHubWithTransport(&pluggableTransport{devices: devices})
+		suite.Require().NoError(err)
+		return hub
+	}
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expErr   error
+		expReady bool
+	}{
+		{
+			"fail - no device connected",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			ledger.ErrNoDevice,
+			false,
+		},
+		{
+			"fail - device unplugged since connecting",
+			func() {
+				suite.ledger.Hub = newHub()
+			},
+			ledger.ErrNoDevice,
+			false,
+		},
+		{
+			"fail - device busy",
+			func() {
+				RegisterOpenBusy(suite.mockWallet)
+			},
+			ledger.ErrDeviceBusy,
+			false,
+		},
+		{
+			"fail - device locked",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningAppError(suite.mockWallet, 0x5515)
+			},
+			ledger.ErrDeviceLocked,
+			false,
+		},
+		{
+			"fail - device on the dashboard",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "BOLOS")
+			},
+			ledger.ErrAppNotOpen,
+			false,
+		},
+		{
+			"fail - another app is open",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "Bitcoin")
+			},
+			ledger.ErrWrongApp,
+			false,
+		},
+		{
+			"pass - ready to sign",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "Ethereum")
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			suite.ledger.Hub = newHub(device)
+			RegisterURL(suite.mockWallet, gethaccounts.URL{Scheme: usbwallet.LedgerScheme, Path: device.Path})
+			tc.mockFunc()
+			ready, err := suite.ledger.IsReady()
+			suite.Require().Equal(tc.expReady, ready)
+			if tc.expReady {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().Error(err)
+				if tc.expErr != nil {
+					suite.Require().ErrorIs(err, tc.expErr)
+				}
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestSupportsEIP712FullDisplay() {
+	testCases := []struct {
+		name       string
+		mockFunc   func()
+		expSupport bool
+		expPass    bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+			false,
+		},
+		{
+			"fail - Ethereum app not open",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfigurationError(suite.mockWallet, 0x6d00)
+			},
+			false,
+			false,
+		},
+		{
+			"pass - version too old",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 9, 18}})
+			},
+			false,
+			true,
+		},
+		{
+			"pass - minimum version",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: ledger.DefaultEIP712FullDisplayVersion})
+			},
+			true,
+			true,
+		},
+		{
+			"pass - newer version",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 10, 2}})
+			},
+			true,
+			true,
+		},
+		{
+			"pass - overridden minimum version",
+			func() {
+				suite.ledger.SetEIP712FullDisplayVersion(1, 11, 0)
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 10, 2}})
+			},
+			false,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			supported, err := suite.ledger.SupportsEIP712FullDisplay()
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expSupport, supported)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestRequestOpenApp() {
+	testCases := []struct {
+		name     string
+		app      string
+		mockFunc func()
+		expErr   error
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			"Cosmos",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			nil,
+			false,
+		},
+		{
+			"fail - empty app name",
+			"",
+			func() {},
+			nil,
+			false,
+		},
+		{
+			"fail - device locked",
+			"Cosmos",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningAppError(suite.mockWallet, 0x5515)
+			},
+			ledger.ErrDeviceLocked,
+			false,
+		},
+		{
+			"fail - another app running",
+			"Cosmos",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "Ethereum")
+			},
+			ledger.ErrWrongApp,
+			false,
+		},
+		{
+			"fail - app not installed",
+			"Cosmos",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "BOLOS")
+				suite.mockWallet.On("OpenApp", "Cosmos").
+					Return(&usbwallet.APDUError{StatusWord: 0x6807})
+			},
+			ledger.ErrAppNotInstalled,
+			false,
+		},
+		{
+			"fail - rejected by the user",
+			"Cosmos",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "BOLOS")
+				suite.mockWallet.On("OpenApp", "Cosmos").
+					Return(&usbwallet.APDUError{StatusWord: 0x6985})
+			},
+			ledger.ErrUserRejected,
+			false,
+		},
+		{
+			"pass - app already running",
+			"Ethereum",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "Ethereum")
+			},
+			nil,
+			true,
+		},
+		{
+			"pass - app opened from the dashboard",
+			"Cosmos",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "BOLOS")
+				suite.mockWallet.On("OpenApp", "Cosmos").
+					Return(nil)
+			},
+			nil,
+			true,
+		},
+		{
+			"pass - running app unknown on older firmware",
+			"Cosmos",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningAppError(suite.mockWallet, 0x6d00)
+				suite.mockWallet.On("OpenApp", "Cosmos").
+					Return(nil)
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			err := suite.ledger.RequestOpenApp(tc.app)
+			if tc.expPass {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().Error(err)
+				if tc.expErr != nil {
+					suite.Require().ErrorIs(err, tc.expErr)
+				}
+			}
+			suite.mockWallet.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestListInstalledApps() {
+	apps := []accounts.AppInfo{
+		{Name: "Ethereum", Hash: make([]byte, 32)},
+		{Name: "Cosmos", Hash: make([]byte, 32)},
+	}
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expErr   error
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			nil,
+			false,
+		},
+		{
+			"fail - device locked",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningAppError(suite.mockWallet, 0x5515)
+			},
+			ledger.ErrDeviceLocked,
+			false,
+		},
+		{
+			"fail - app running",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "Ethereum")
+			},
+			ledger.ErrWrongApp,
+			false,
+		},
+		{
+			"fail - apps not disclosed",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "BOLOS")
+				suite.mockWallet.On("ListApps").
+					Return(nil, &usbwallet.APDUError{StatusWord: 0x6a81})
+			},
+			nil,
+			false,
+		},
+		{
+			"pass - apps listed from the dashboard",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningApp(suite.mockWallet, "BOLOS")
+				suite.mockWallet.On("ListApps").
+					Return(apps, nil)
+			},
+			nil,
+			true,
+		},
+		{
+			"pass - running app unknown on older firmware",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterRunningAppError(suite.mockWallet, 0x6d00)
+				suite.mockWallet.On("ListApps").
+					Return(apps, nil)
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			installed, err := suite.ledger.ListInstalledApps()
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(apps, installed)
+			} else {
+				suite.Require().Error(err)
+				if tc.expErr != nil {
+					suite.Require().ErrorIs(err, tc.expErr)
+				}
+			}
+			suite.mockWallet.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestBlindSigningEnabled() {
+	testCases := []struct {
+		name       string
+		mockFunc   func()
+		expEnabled bool
+		expPass    bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+			false,
+		},
+		{
+			"fail - Ethereum app not open",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfigurationError(suite.mockWallet, 0x6d00)
+			},
+			false,
+			false,
+		},
+		{
+			"pass - blind signing disabled",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 10, 2}})
+			},
+			false,
+			true,
+		},
+		{
+			"pass - blind signing enabled",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 10, 2}, BlindSigning: true})
+			},
+			true,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			enabled, err := suite.ledger.BlindSigningEnabled()
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expEnabled, enabled)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestBlindSigningDisabledError() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	testCases := []struct {
+		name         string
+		blindSigning bool
+		expDisabled  bool
+	}{
+		{"blind signing disabled", false, true},
+		{"data rejected for another reason", true, false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			RegisterOpen(suite.mockWallet)
+			RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			RegisterSignTypedDataAPDUError(suite.mockWallet, account, suite.txAmino, 0x6a80)
+			RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 10, 2}, BlindSigning: tc.blindSigning})
+
+			_, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+			suite.Require().ErrorIs(err, ledger.ErrInvalidData)
+			if tc.expDisabled {
+				suite.Require().ErrorIs(err, ledger.ErrBlindSigningDisabled)
+			} else {
+				suite.Require().NotErrorIs(err, ledger.ErrBlindSigningDisabled)
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestAutoOpenApp() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name          string
+		autoOpenApp   bool
+		openAppStatus []byte
+		expErr        error
+	}{
+		{"fail - auto-open disabled", false, []byte{0x90, 0x00}, ledger.ErrAppNotOpen},
+		{"fail - opening declined by the user", true, []byte{0x69, 0x85}, ledger.ErrUserRejected},
+		{"pass - Ethereum app launched", true, []byte{0x90, 0x00}, nil},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			endpoint := suite.serveSpeculos(&privKey.PublicKey, "BOLOS", tc.openAppStatus)
+			evmosSECP256K1 := ledger.NewEvmosSECP256K1(
+				ledger.WithSpeculosEndpoint(endpoint),
+				ledger.WithAutoOpenApp(tc.autoOpenApp),
+			)
+
+			device, err := evmosSECP256K1.Connect()
+			if tc.expErr != nil {
+				suite.Require().ErrorIs(err, ledger.ErrAppNotOpen)
+				suite.Require().ErrorIs(err, tc.expErr)
+				return
+			}
+			suite.Require().NoError(err)
+			defer device.Close()
+
+			pubKey, err := device.GetPublicKeySECP256K1(gethaccounts.DefaultBaseDerivationPath)
+			suite.Require().NoError(err)
+			suite.Require().Equal(crypto.FromECDSAPub(&privKey.PublicKey), pubKey)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// BatchError is returned by SignBatch when one of the sign docs could not be
+// signed, e.g. because the user rejected it. It unwraps to the signing error.
+type BatchError struct {
+	Index int   // Index of the sign doc that failed
+	Err   error // Error returned for that sign doc
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("unable to sign batch item %d: %s", e.Index, e.Err)
+}
+
+// Unwrap returns the error of the sign doc that failed.
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// SignBatch signs multiple sign docs with the account derived from the provided
+// hdPath, e.g. to sign many authz grants, returning the signatures in the same order
+// and format as SignSECP256K1. The account is derived once and the device is held
+// for the whole batch, so no other request is interleaved. The Ethereum app has no
+// batch signing, so each sign doc still needs to be confirmed on the device. If a
+// sign doc can't be signed, the signatures of the previous ones are returned along
+// with a *BatchError holding the index of the failed sign doc; the following ones
+// are not sent to the device. No sign doc is sent if one of them is invalid. If the
+// batch is aborted (see AbortCurrentOperation), ErrAborted is returned without any
+// signature, and the following sign docs are not sent to the device.
+func (e *EvmosSECP256K1) SignBatch(hdPath []uint32, signDocs [][]byte) ([][]byte, error) {
+	e.promptSigning()
+	e.reportStage(StageBuild)
+
+	typedDatas := make([]apitypes.TypedData, len(signDocs))
+	for i, signDocBytes := range signDocs {
+		typedData, err := e.buildTypedData(signDocBytes)
+		if err != nil {
+			return nil, &BatchError{Index: i, Err: err}
+		}
+		typedDatas[i] = typedData
+	}
+
+	var signatures [][]byte
+	_, err := e.signWithContext(context.Background(), func(ctx context.Context) (_ []byte, err error) {
+		signatures, err = e.signBatch(ctx, hdPath, typedDatas)
+		return nil, err
+	})
+
+	// The signatures are only returned along with the error of a sign doc, since the
+	// batch may still be running if it was aborted
+	var batchErr *BatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return nil, err
+	}
+
+	return signatures, err
+}
+
+// signBatch signs the typed data one after the other with the account derived from
+// hdPath, stopping at the first failure or once the context is done.
+//
+// Note, signBatch assumes the lock is held!
+func (e *EvmosSECP256K1) signBatch(ctx context.Context, hdPath []uint32, typedDatas []apitypes.TypedData) ([][]byte, error) {
+	if err := e.prepareSign(ctx); err != nil {
+		return nil, err
+	}
+
+	account, err := e.deriveSigner(ctx, hdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([][]byte, 0, len(typedDatas))
+	for i, typedData := range typedDatas {
+		if ctx.Err() != nil {
+			return signatures, &BatchError{Index: i, Err: context.Cause(ctx)}
+		}
+
+		signature, _, err := e.signTypedDataWithAccount(ctx, hdPath, account, typedData)
+		if err == nil {
+			err = e.verifyTypedDataSignature(ctx, hdPath, typedData, signature)
+		}
+		if err != nil {
+			return signatures, &BatchError{Index: i, Err: err}
+		}
+
+		signatures = append(signatures, signature)
+	}
+
+	return signatures, nil
+}
@@ -0,0 +1,119 @@
+package ledger_test
+
+import (
+	"bytes"
+	"errors"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestSignBatch() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	// Next transaction of the same account
+	nextTx := bytes.Replace(suite.txAmino, []byte(`"sequence":"6"`), []byte(`"sequence":"7"`), 1)
+
+	testCases := []struct {
+		name        string
+		signDocs    [][]byte
+		mockFunc    func()
+		expSigs     int
+		expIndex    int
+		expErr      error
+		expPass     bool
+		expBatchErr bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			[][]byte{suite.txAmino},
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			0,
+			0,
+			nil,
+			false,
+			false,
+		},
+		{
+			"fail - invalid sign doc",
+			[][]byte{suite.txAmino, []byte("invalid")},
+			func() {},
+			0,
+			1,
+			nil,
+			false,
+			true,
+		},
+		{
+			"fail - second sign doc rejected",
+			[][]byte{suite.txAmino, nextTx},
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+				RegisterSignTypedDataRejected(suite.mockWallet, account, nextTx)
+			},
+			1,
+			1,
+			ledger.ErrUserRejected,
+			false,
+			true,
+		},
+		{
+			"pass - all sign docs signed",
+			[][]byte{suite.txAmino, nextTx},
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+				RegisterSignTypedData(suite.mockWallet, account, nextTx)
+			},
+			2,
+			0,
+			nil,
+			true,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			signatures, err := suite.ledger.SignBatch(gethaccounts.DefaultBaseDerivationPath, tc.signDocs)
+			suite.Require().Len(signatures, tc.expSigs)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				for _, signature := range signatures {
+					suite.Require().Len(signature, crypto.SignatureLength)
+				}
+
+				// The account is derived once for the whole batch
+				suite.mockWallet.AssertNumberOfCalls(suite.T(), "Derive", 1)
+				return
+			}
+
+			suite.Require().Error(err)
+			if tc.expErr != nil {
+				suite.Require().ErrorIs(err, tc.expErr)
+			}
+
+			var batchErr *ledger.BatchError
+			suite.Require().Equal(tc.expBatchErr, errors.As(err, &batchErr))
+			if tc.expBatchErr {
+				suite.Require().Equal(tc.expIndex, batchErr.Index)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package ledger
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+)
+
+// hardenedOffset is the offset added to the index of hardened derivation path components.
+const hardenedOffset = 0x80000000
+
+// SetCacheEnabled enables or disables caching the accounts derived by
+// GetPublicKeySECP256K1 and GetAddressPubKeySECP256K1, keyed by HD path. Since the
+// public key for a given path never changes, the cache avoids slow round-trips to
+// the device when the same accounts are queried repeatedly. The cache is cleared
+// whenever the wallet is closed or another device is selected. Caching is disabled
+// by default.
+func (e *EvmosSECP256K1) SetCacheEnabled(enabled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.cacheEnabled = enabled
+	e.clearCache()
+}
+
+// ClearCache drops all the cached accounts, e.g. after the device was swapped.
+func (e *EvmosSECP256K1) ClearCache() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.clearCache()
+}
+
+// clearCache drops all the cached accounts.
+//
+// Note, clearCache assumes the lock is held!
+func (e *EvmosSECP256K1) clearCache() {
+	e.cache = nil
+}
+
+// wipeCache overwrites the public keys of the cached accounts before dropping them,
+// so that no derived key material lingers in memory. The cache holds its own copies
+// of the public keys, so the accounts handed to the callers are left untouched.
+//
+// Note, wipeCache assumes the lock is held!
+func (e *EvmosSECP256K1) wipeCache() {
+	for key, account := range e.cache {
+		if account.PublicKey != nil {
+			wipeBigInt(account.PublicKey.X)
+			wipeBigInt(account.PublicKey.Y)
+		}
+		e.cache[key] = accounts.Account{}
+	}
+
+	e.clearCache()
+}
+
+// wipeBigInt overwrites the words backing the integer with zeros.
+func wipeBigInt(x *big.Int) {
+	if x == nil {
+		return
+	}
+
+	words := x.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	x.SetInt64(0)
+}
+
+// cachedAccount returns the cached account derived at the given HD path, if any.
+//
+// Note, cachedAccount assumes the lock is held!
+func (e *EvmosSECP256K1) cachedAccount(hdPath []uint32) (accounts.Account, bool) {
+	if !e.cacheEnabled {
+		return accounts.Account{}, false
+	}
+
+	account, ok := e.cache[cacheKey(hdPath)]
+	if !ok {
+		return accounts.Account{}, false
+	}
+	return copyAccount(account), true
+}
+
+// cacheAccount stores the account derived at the given HD path.
+//
+// Note, cacheAccount assumes the lock is held!
+func (e *EvmosSECP256K1) cacheAccount(hdPath []uint32, account accounts.Account) {
+	if !e.cacheEnabled {
+		return
+	}
+
+	if e.cache == nil {
+		e.cache = make(map[string]accounts.Account)
+	}
+	e.cache[cacheKey(hdPath)] = copyAccount(account)
+}
+
+// copyAccount returns a copy of the account with its own public key, so that wiping
+// either of them doesn't affect the other.
+func copyAccount(account accounts.Account) accounts.Account {
+	if account.PublicKey != nil {
+		account.PublicKey = &ecdsa.PublicKey{
+			Curve: account.PublicKey.Curve,
+			X:     new(big.Int).Set(account.PublicKey.X),
+			Y:     new(big.Int).Set(account.PublicKey.Y),
+		}
+	}
+	return account
+}
+
+// cacheKey serializes the HD path into the key used for the cache. The purpose,
+// coin type and account components are always hardened before deriving, so they
+// are hardened in the key too.
+func cacheKey(hdPath []uint32) string {
+	path := make(gethaccounts.DerivationPath, len(hdPath))
+	copy(path, hdPath)
+
+	for i := 0; i < 3 && i < len(path); i++ {
+		if path[i] < hardenedOffset {
+			path[i] += hardenedOffset
+		}
+	}
+
+	return path.String()
+}
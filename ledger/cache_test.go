@@ -0,0 +1,106 @@
+package ledger_test
+
+import (
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func (suite *LedgerTestSuite) TestPublicKeyCache() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	expPubkeyBz := crypto.FromECDSAPub(&privKey.PublicKey)
+
+	testCases := []struct {
+		name           string
+		enabled        bool
+		actionFunc     func()
+		expDeriveCalls int
+	}{
+		{
+			"cache disabled - every request hits the device",
+			false,
+			func() {},
+			2,
+		},
+		{
+			"cache enabled - second request is served from the cache",
+			true,
+			func() {},
+			1,
+		},
+		{
+			"cache enabled - cleared explicitly",
+			true,
+			func() {
+				suite.ledger.ClearCache()
+			},
+			2,
+		},
+		{
+			"cache enabled - cleared on close",
+			true,
+			func() {
+				RegisterClose(suite.mockWallet)
+				suite.Require().NoError(suite.ledger.Close())
+
+				// Reconnect, as the derivation function would
+				suite.ledger.PrimaryWallet = suite.mockWallet
+			},
+			2,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			RegisterOpen(suite.mockWallet)
+			RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			suite.ledger.SetCacheEnabled(tc.enabled)
+
+			pubKeyBz, err := suite.ledger.GetPublicKeySECP256K1(gethaccounts.DefaultBaseDerivationPath)
+			suite.Require().NoError(err)
+			suite.Require().Equal(expPubkeyBz, pubKeyBz)
+
+			tc.actionFunc()
+
+			_, _, err = suite.ledger.GetAddressPubKeySECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.hrp)
+			suite.Require().NoError(err)
+
+			suite.mockWallet.AssertNumberOfCalls(suite.T(), "Derive", tc.expDeriveCalls)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestCloseWipesCache() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	RegisterOpen(suite.mockWallet)
+	RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+	RegisterClose(suite.mockWallet)
+	suite.ledger.SetCacheEnabled(true)
+
+	account, _, err := suite.ledger.GetAccountSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.hrp)
+	suite.Require().NoError(err)
+
+	// Served from the cache
+	cachedAccount, _, err := suite.ledger.GetAccountSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.hrp)
+	suite.Require().NoError(err)
+	suite.mockWallet.AssertNumberOfCalls(suite.T(), "Derive", 1)
+
+	suite.Require().NoError(suite.ledger.Close())
+	suite.Require().Nil(suite.ledger.PrimaryWallet)
+
+	// Only the copies held by the cache were overwritten, not the accounts returned
+	// to the caller nor the public key of the wallet
+	expPubKeyBz := crypto.FromECDSAPub(&privKey.PublicKey)
+	suite.Require().NotZero(privKey.PublicKey.X.Sign())
+	suite.Require().Equal(expPubKeyBz, crypto.FromECDSAPub(account.PublicKey))
+	suite.Require().Equal(expPubKeyBz, crypto.FromECDSAPub(cachedAccount.PublicKey))
+
+	// Any further request fails until the device is connected again
+	_, err = suite.ledger.GetPublicKeySECP256K1(gethaccounts.DefaultBaseDerivationPath)
+	suite.Require().Error(err)
+}
@@ -0,0 +1,57 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// ErrChainIDMismatch is returned when the chain ID of the EIP-712 domain to sign
+// differs from the expected one (see SetExpectedChainID).
+var ErrChainIDMismatch = errors.New("EIP-712 domain chain ID mismatch")
+
+// SetExpectedChainID makes every EIP-712 signing request check that the chain ID of
+// the typed data domain equals the given one, and fail with ErrChainIDMismatch
+// otherwise, e.g. so that a message intended for mainnet is never signed against a
+// testnet domain because of a misconfigured sign doc. If an HRP is registered for
+// the chain ID (see RegisterHRP), addresses are also required to be encoded with it.
+// Passing nil disables the check, which is the default.
+func (e *EvmosSECP256K1) SetExpectedChainID(chainID *big.Int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.hrpMu.Lock()
+	defer e.hrpMu.Unlock()
+
+	if chainID == nil {
+		e.expectedChainID = nil
+		e.hrpChainID = ""
+		return
+	}
+
+	e.expectedChainID = new(big.Int).Set(chainID)
+	e.hrpChainID = chainID.String()
+}
+
+// verifyChainID checks the chain ID of the typed data domain against the expected
+// one, if any.
+//
+// Note, verifyChainID assumes the lock is held!
+func (e *EvmosSECP256K1) verifyChainID(typedData apitypes.TypedData) error {
+	if e.expectedChainID == nil {
+		return nil
+	}
+
+	if typedData.Domain.ChainId == nil {
+		return fmt.Errorf("%w: domain has no chain ID, expected %s", ErrChainIDMismatch, e.expectedChainID)
+	}
+
+	chainID := (*big.Int)(typedData.Domain.ChainId)
+	if chainID.Cmp(e.expectedChainID) != 0 {
+		return fmt.Errorf("%w: domain has chain ID %s, expected %s", ErrChainIDMismatch, chainID, e.expectedChainID)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package ledger_test
+
+import (
+	"math/big"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestExpectedChainID() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	testCases := []struct {
+		name    string
+		chainID *big.Int
+		expPass bool
+	}{
+		{
+			"pass - chain ID not checked",
+			nil,
+			true,
+		},
+		{
+			"pass - chain ID of the sign doc",
+			big.NewInt(9000),
+			true,
+		},
+		{
+			"fail - chain ID mismatch",
+			big.NewInt(9001),
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			ledger.WithExpectedChainID(tc.chainID)(suite.ledger)
+			RegisterOpen(suite.mockWallet)
+			RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+
+			_, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+			if !tc.expPass {
+				suite.Require().ErrorIs(err, ledger.ErrChainIDMismatch)
+				suite.mockWallet.AssertNotCalled(suite.T(), "SignTypedData", account, mock.Anything)
+				return
+			}
+
+			suite.Require().NoError(err)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+)
+
+// SetForceConfirmation enables or disables requiring an explicit confirmation on
+// the device for every signature. The Ethereum app already asks the user to approve
+// each signing request, which this doesn't change; if enabled, the Ledger first
+// displays the address of the signing account as well, and the request is only
+// sent once the user physically confirms it. ErrUserRejected is returned if the user
+// declines the address. It is disabled by default.
+func (e *EvmosSECP256K1) SetForceConfirmation(force bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.forceConfirmation = force
+}
+
+// deriveSigner derives the account signing with the given HD path, showing its
+// address on the device for the user to confirm if confirmations are forced.
+//
+// Note, deriveSigner assumes the lock is held!
+func (e *EvmosSECP256K1) deriveSigner(ctx context.Context, hdPath []uint32) (accounts.Account, error) {
+	e.reportStage(StageDerive)
+
+	if e.forceConfirmation {
+		e.prompt("Please confirm the signing address displayed on your Ledger...")
+	}
+
+	account, err := e.derive(ctx, hdPath, e.forceConfirmation)
+	if err != nil {
+		return accounts.Account{}, fmt.Errorf("unable to derive Ledger address, please open the Ethereum app and retry: %w", err)
+	}
+
+	return account, nil
+}
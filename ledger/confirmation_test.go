@@ -0,0 +1,76 @@
+package ledger_test
+
+import (
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestForceConfirmation() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	testCases := []struct {
+		name     string
+		force    bool
+		mockFunc func()
+		expErr   error
+		expPass  bool
+	}{
+		{
+			"pass - address not displayed by default",
+			false,
+			func() {
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+			nil,
+			true,
+		},
+		{
+			"pass - address confirmed before signing",
+			true,
+			func() {
+				RegisterDeriveWithDisplay(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+			nil,
+			true,
+		},
+		{
+			"fail - address rejected by the user",
+			true,
+			func() {
+				RegisterDeriveWithDisplayRejected(suite.mockWallet)
+			},
+			ledger.ErrUserRejected,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			ledger.WithForceConfirmation(tc.force)(suite.ledger)
+			RegisterOpen(suite.mockWallet)
+			tc.mockFunc()
+
+			signature, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+			if !tc.expPass {
+				suite.Require().ErrorIs(err, tc.expErr)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Len(signature, crypto.SignatureLength)
+			suite.mockWallet.AssertExpectations(suite.T())
+		})
+	}
+}
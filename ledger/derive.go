@@ -0,0 +1,306 @@
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+)
+
+// AccountInfo describes an account derived from the Ledger.
+type AccountInfo struct {
+	HDPath  []uint32 `json:"hdPath"`  // Full HD path of the account
+	PubKey  []byte   `json:"pubKey"`  // Uncompressed secp256k1 public key
+	Address string   `json:"address"` // Bech32 address encoded with the requested HRP
+}
+
+// DeriveAccount derives the account at the given HD path, which can then be passed
+// to SignSECP256K1WithAccount to sign without deriving it again.
+func (e *EvmosSECP256K1) DeriveAccount(hdPath []uint32) (accounts.Account, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return accounts.Account{}, errors.New("could not derive Ledger account: no wallet found")
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return accounts.Account{}, err
+	}
+
+	// Always query the device, so the wallet tracks the account for signing
+	account, err := e.derive(context.Background(), hdPath, false)
+	if err != nil {
+		return accounts.Account{}, fmt.Errorf("unable to derive Ledger address, please open the Ethereum app and retry: %w", err)
+	}
+
+	return account, nil
+}
+
+// GetAccountSECP256K1 behaves like GetAddressPubKeySECP256K1, but returns the full
+// account along with the bech32 address, which gives access to the hex Ethereum
+// address and the URL of the derivation path as well, e.g. for wallets displaying
+// both the Cosmos and the EVM address of an account.
+func (e *EvmosSECP256K1) GetAccountSECP256K1(hdPath []uint32, hrp string) (accounts.Account, string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return accounts.Account{}, "", errors.New("could not get Ledger account: no wallet found")
+	}
+
+	if err := e.validateHRP(hrp); err != nil {
+		return accounts.Account{}, "", err
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return accounts.Account{}, "", err
+	}
+
+	return e.getAccount(hdPath, hrp, false)
+}
+
+// HexAddressChecksummed returns the Ethereum address of the account derived at the
+// given HD path as an EIP-55 checksummed hex string (e.g. "0xAbC..."), which is the
+// canonical display form in EVM tooling (see FormatHexAddress).
+func (e *EvmosSECP256K1) HexAddressChecksummed(hdPath []uint32) (string, error) {
+	account, err := e.sharedDerive(hdPath)
+	if err != nil {
+		return "", err
+	}
+
+	return FormatHexAddress(account.Address.Bytes())
+}
+
+// GetAddresses returns both address forms of the account derived at the given HD
+// path in a single round-trip to the device: the hex Ethereum address (e.g.
+// "0x...") and the bech32 address encoded with the configured "Human Readable
+// Prefix" (see SetHRP), along with the uncompressed public key.
+func (e *EvmosSECP256K1) GetAddresses(hdPath []uint32) (hex string, bech32 string, pubKey []byte, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return "", "", nil, errors.New("could not get Ledger addresses: no wallet found")
+	}
+
+	hrp := e.getHRP()
+	if err := e.validateHRP(hrp); err != nil {
+		return "", "", nil, err
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return "", "", nil, err
+	}
+
+	account, address, err := e.getAccount(hdPath, hrp, false)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	return account.Address.Hex(), address, crypto.FromECDSAPub(account.PublicKey), nil
+}
+
+// DeriveAccounts derives count consecutive accounts, starting from basePath and
+// incrementing its last component (i.e. the address index), and encodes their
+// addresses with the given "Human Readable Prefix". All the accounts are derived
+// within a single session, which is faster than calling GetAddressPubKeySECP256K1
+// for each of them when scanning the accounts of a device.
+func (e *EvmosSECP256K1) DeriveAccounts(basePath []uint32, count int, hrp string) ([]AccountInfo, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return nil, errors.New("could not derive Ledger accounts: no wallet found")
+	}
+
+	if len(basePath) == 0 {
+		return nil, errors.New("could not derive Ledger accounts: empty HD path")
+	}
+
+	if count <= 0 {
+		return nil, fmt.Errorf("could not derive Ledger accounts: invalid count %d", count)
+	}
+
+	// The address index must not overflow into the hardened range, or out of it
+	last := len(basePath) - 1
+	firstIndex := uint64(basePath[last])
+	lastIndex := firstIndex + uint64(count) - 1
+	if (firstIndex < hardenedOffset) != (lastIndex < hardenedOffset) || lastIndex > 0xffffffff {
+		return nil, fmt.Errorf("could not derive Ledger accounts: %d accounts from index %d overflow the HD path component", count, firstIndex)
+	}
+
+	if err := e.validateHRP(hrp); err != nil {
+		return nil, err
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return nil, err
+	}
+
+	infos := make([]AccountInfo, 0, count)
+	for i := 0; i < count; i++ {
+		hdPath := make([]uint32, len(basePath))
+		copy(hdPath, basePath)
+		hdPath[last] += uint32(i)
+
+		account, err := e.cachedDerive(context.Background(), hdPath, false)
+		if err != nil {
+			return nil, fmt.Errorf("unable to derive Ledger address, please open the Ethereum app and retry: %w", err)
+		}
+
+		address, err := FormatBech32(account.Address.Bytes(), hrp)
+		if err != nil {
+			return nil, err
+		}
+
+		infos = append(infos, AccountInfo{
+			HDPath:  hdPath,
+			PubKey:  crypto.FromECDSAPub(account.PublicKey),
+			Address: address,
+		})
+	}
+
+	return infos, nil
+}
+
+// ExportedAccount describes an account derived from the Ledger with textual fields
+// only, so it can be serialized to JSON and consumed by external tools.
+type ExportedAccount struct {
+	Path          string `json:"path"`          // HD path of the account (e.g. "m/44'/60'/0'/0/0")
+	HexAddress    string `json:"hexAddress"`    // EIP-55 checksummed hex Ethereum address
+	Bech32Address string `json:"bech32Address"` // Bech32 address encoded with the requested HRP
+	PubKeyHex     string `json:"pubkeyHex"`     // 0x-prefixed hex uncompressed secp256k1 public key
+}
+
+// ExportAccounts behaves like DeriveAccounts, but returns the accounts in a
+// JSON-serializable form including both address forms, e.g. to export the first
+// accounts of a device to a configuration file.
+func (e *EvmosSECP256K1) ExportAccounts(basePath []uint32, count int, hrp string) ([]ExportedAccount, error) {
+	infos, err := e.DeriveAccounts(basePath, count, hrp)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := make([]ExportedAccount, 0, len(infos))
+	for _, info := range infos {
+		hexAddress, err := FormatHexAddress(info.PubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		exported = append(exported, ExportedAccount{
+			Path:          gethaccounts.DerivationPath(info.HDPath).String(),
+			HexAddress:    hexAddress,
+			Bech32Address: info.Address,
+			PubKeyHex:     hexutil.Encode(info.PubKey),
+		})
+	}
+
+	return exported, nil
+}
+
+// VerifyAddress derives the account at the given HD path from the device and checks
+// that its address, encoded with the given "Human Readable Prefix", matches the
+// expected one, e.g. to make sure an account stored in a keyring still belongs to
+// the connected Ledger after a seed restore. ErrAddressMismatch is returned if the
+// addresses differ. The cached public keys are bypassed, so the result always
+// reflects the current seed of the device.
+func (e *EvmosSECP256K1) VerifyAddress(hdPath []uint32, expected string, hrp string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return errors.New("could not verify Ledger address: no wallet found")
+	}
+
+	if err := e.validateHRP(hrp); err != nil {
+		return err
+	}
+
+	expectedBz, err := decodeBech32(expected, hrp)
+	if err != nil {
+		return fmt.Errorf("could not verify Ledger address: invalid expected address %q: %w", expected, err)
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return err
+	}
+
+	account, err := e.derive(context.Background(), hdPath, false)
+	if err != nil {
+		return fmt.Errorf("unable to derive Ledger address, please open the Ethereum app and retry: %w", err)
+	}
+
+	if !bytes.Equal(expectedBz, account.Address.Bytes()) {
+		address, err := FormatBech32(account.Address.Bytes(), hrp)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: expected %s, device derived %s at %s", ErrAddressMismatch, expected, address, gethaccounts.DerivationPath(hdPath))
+	}
+
+	return nil
+}
+
+// DiscoverAccounts implements the BIP-44 account discovery for users restoring an
+// existing seed: it derives the accounts of the first account level (i.e.
+// m/44'/coin_type'/0'/0/index) with increasing address indices, and stops once
+// gapLimit consecutive accounts are reported as unused by isUsed, which is called
+// with the address of each account encoded with the given "Human Readable Prefix"
+// (e.g. to check its balance or nonce). The used accounts are returned.
+//
+// The accounts are derived in batches of gapLimit, and the lock is released while
+// isUsed runs, so slow checks don't block other requests to the device.
+func (e *EvmosSECP256K1) DiscoverAccounts(hrp string, gapLimit int, isUsed func(address string) bool) ([]AccountInfo, error) {
+	if gapLimit <= 0 {
+		return nil, fmt.Errorf("could not discover Ledger accounts: invalid gap limit %d", gapLimit)
+	}
+
+	if isUsed == nil {
+		return nil, errors.New("could not discover Ledger accounts: no usage check provided")
+	}
+
+	e.mu.Lock()
+	coinType := e.expectedCoinType()
+	e.mu.Unlock()
+
+	basePath := []uint32{hardenedOffset + bip44Purpose, hardenedOffset + coinType, hardenedOffset, 0, 0}
+
+	var (
+		used []AccountInfo
+		gap  int
+	)
+	for {
+		infos, err := e.DeriveAccounts(basePath, gapLimit, hrp)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, info := range infos {
+			if isUsed(info.Address) {
+				used = append(used, info)
+				gap = 0
+				continue
+			}
+
+			gap++
+			if gap == gapLimit {
+				return used, nil
+			}
+		}
+
+		basePath[len(basePath)-1] += uint32(gapLimit)
+	}
+}
@@ -0,0 +1,645 @@
+package ledger_test
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestGetAccountSECP256K1() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	expAccount := accounts.Account{
+		Address:   crypto.PubkeyToAddress(privKey.PublicKey),
+		PublicKey: &privKey.PublicKey,
+		URL:       gethaccounts.URL{Scheme: "ledger", Path: "/dev/hidraw0/m/44'/60'/0'/0/0"},
+	}
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+		},
+		{
+			"fail - invalid HRP",
+			func() {
+				suite.hrp = ""
+			},
+			false,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			false,
+		},
+		{
+			"pass - account and address returned",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				suite.mockWallet.On("Derive", gethaccounts.DefaultBaseDerivationPath, true).
+					Return(expAccount, nil)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			account, addr, err := suite.ledger.GetAccountSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.hrp)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(expAccount, account)
+
+			expAddr, err := sdk.Bech32ifyAddressBytes(suite.hrp, expAccount.Address.Bytes())
+			suite.Require().NoError(err)
+			suite.Require().Equal(expAddr, addr)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestDeriveAccounts() {
+	count := 3
+	keys := make([]*ecdsa.PrivateKey, count)
+	paths := make([]gethaccounts.DerivationPath, count)
+	for i := range keys {
+		privKey, err := crypto.GenerateKey()
+		suite.Require().NoError(err)
+		keys[i] = privKey
+
+		path := make(gethaccounts.DerivationPath, len(gethaccounts.DefaultBaseDerivationPath))
+		copy(path, gethaccounts.DefaultBaseDerivationPath)
+		path[len(path)-1] += uint32(i)
+		paths[i] = path
+	}
+
+	testCases := []struct {
+		name     string
+		basePath []uint32
+		count    int
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			gethaccounts.DefaultBaseDerivationPath,
+			count,
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+		},
+		{
+			"fail - invalid count",
+			gethaccounts.DefaultBaseDerivationPath,
+			0,
+			func() {},
+			false,
+		},
+		{
+			"fail - empty HD path",
+			[]uint32{},
+			count,
+			func() {},
+			false,
+		},
+		{
+			"fail - address index overflows into the hardened range",
+			[]uint32{0x8000002c, 0x8000003c, 0x80000000, 0, 0x7fffffff},
+			count,
+			func() {},
+			false,
+		},
+		{
+			"fail - invalid HRP",
+			gethaccounts.DefaultBaseDerivationPath,
+			count,
+			func() {
+				suite.hrp = ""
+			},
+			false,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			gethaccounts.DefaultBaseDerivationPath,
+			count,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			false,
+		},
+		{
+			"pass - accounts derived",
+			gethaccounts.DefaultBaseDerivationPath,
+			count,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				for i, path := range paths {
+					RegisterDeriveForPath(suite.mockWallet, path, crypto.PubkeyToAddress(keys[i].PublicKey), &keys[i].PublicKey)
+				}
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			infos, err := suite.ledger.DeriveAccounts(tc.basePath, tc.count, suite.hrp)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Len(infos, count)
+			for i, info := range infos {
+				expAddr, err := sdk.Bech32ifyAddressBytes(suite.hrp, crypto.PubkeyToAddress(keys[i].PublicKey).Bytes())
+				suite.Require().NoError(err)
+
+				suite.Require().Equal([]uint32(paths[i]), info.HDPath)
+				suite.Require().Equal(crypto.FromECDSAPub(&keys[i].PublicKey), info.PubKey)
+				suite.Require().Equal(expAddr, info.Address)
+			}
+
+			// The wallet is opened once for the whole batch
+			suite.mockWallet.AssertNumberOfCalls(suite.T(), "Open", 1)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestExportAccounts() {
+	count := 3
+	keys := make([]*ecdsa.PrivateKey, count)
+	for i := range keys {
+		privKey, err := crypto.GenerateKey()
+		suite.Require().NoError(err)
+		keys[i] = privKey
+	}
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			false,
+		},
+		{
+			"pass - accounts exported",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				for i, key := range keys {
+					suite.registerDeriveAtIndex(key, i)
+				}
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			exported, err := suite.ledger.ExportAccounts(gethaccounts.DefaultBaseDerivationPath, count, suite.hrp)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Len(exported, count)
+			for i, account := range exported {
+				addr := crypto.PubkeyToAddress(keys[i].PublicKey)
+				expBech32, err := sdk.Bech32ifyAddressBytes(suite.hrp, addr.Bytes())
+				suite.Require().NoError(err)
+
+				suite.Require().Equal(fmt.Sprintf("m/44'/60'/0'/0/%d", i), account.Path)
+				suite.Require().Equal(addr.Hex(), account.HexAddress)
+				suite.Require().Equal(expBech32, account.Bech32Address)
+				suite.Require().Equal(hexutil.Encode(crypto.FromECDSAPub(&keys[i].PublicKey)), account.PubKeyHex)
+			}
+
+			bz, err := json.Marshal(exported[0])
+			suite.Require().NoError(err)
+			suite.Require().JSONEq(fmt.Sprintf(
+				`{"path":%q,"hexAddress":%q,"bech32Address":%q,"pubkeyHex":%q}`,
+				exported[0].Path, exported[0].HexAddress, exported[0].Bech32Address, exported[0].PubKeyHex,
+			), string(bz))
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestSignSECP256K1WithAccount() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			false,
+		},
+		{
+			"pass - account derived once for display and signing",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedData(suite.mockWallet, accounts.Account{Address: addr, PublicKey: &privKey.PublicKey}, suite.txAmino)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			account, err := suite.ledger.DeriveAccount(gethaccounts.DefaultBaseDerivationPath)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(addr, account.Address)
+
+			signature, err := suite.ledger.SignSECP256K1WithAccount(account, suite.txAmino)
+			suite.Require().NoError(err)
+			suite.Require().Len(signature, crypto.SignatureLength)
+
+			suite.mockWallet.AssertNumberOfCalls(suite.T(), "Derive", 1)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestVerifyAddress() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	expAddr, err := sdk.Bech32ifyAddressBytes(suite.hrp, addr.Bytes())
+	suite.Require().NoError(err)
+
+	otherKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	otherAddr, err := sdk.Bech32ifyAddressBytes(suite.hrp, crypto.PubkeyToAddress(otherKey.PublicKey).Bytes())
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name     string
+		expected string
+		mockFunc func()
+		expPass  bool
+		expErr   error
+	}{
+		{
+			"fail - can't find Ledger device",
+			expAddr,
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+			nil,
+		},
+		{
+			"fail - invalid expected address",
+			"evmos1invalid",
+			func() {},
+			false,
+			nil,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			expAddr,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			false,
+			nil,
+		},
+		{
+			"fail - address mismatch",
+			otherAddr,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			false,
+			ledger.ErrAddressMismatch,
+		},
+		{
+			"pass - address matches",
+			expAddr,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			true,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			err := suite.ledger.VerifyAddress(gethaccounts.DefaultBaseDerivationPath, tc.expected, suite.hrp)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				if tc.expErr != nil {
+					suite.Require().ErrorIs(err, tc.expErr)
+				}
+				return
+			}
+
+			suite.Require().NoError(err)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestDiscoverAccounts() {
+	gapLimit := 2
+	keys := make([]*ecdsa.PrivateKey, 3*gapLimit)
+	for i := range keys {
+		privKey, err := crypto.GenerateKey()
+		suite.Require().NoError(err)
+		keys[i] = privKey
+	}
+
+	address := func(i int) string {
+		addr, err := sdk.Bech32ifyAddressBytes(suite.hrp, crypto.PubkeyToAddress(keys[i].PublicKey).Bytes())
+		suite.Require().NoError(err)
+		return addr
+	}
+
+	testCases := []struct {
+		name       string
+		gapLimit   int
+		isUsed     func(address string) bool
+		mockFunc   func()
+		expPass    bool
+		expIndices []int
+	}{
+		{
+			"fail - invalid gap limit",
+			0,
+			func(string) bool { return false },
+			func() {},
+			false,
+			nil,
+		},
+		{
+			"fail - no usage check",
+			gapLimit,
+			nil,
+			func() {},
+			false,
+			nil,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			gapLimit,
+			func(string) bool { return false },
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			false,
+			nil,
+		},
+		{
+			"pass - no used account",
+			gapLimit,
+			func(string) bool { return false },
+			func() {
+				RegisterOpen(suite.mockWallet)
+				for i := range keys {
+					suite.registerDeriveAtIndex(keys[i], i)
+				}
+			},
+			true,
+			nil,
+		},
+		{
+			"pass - scan stops after the gap limit",
+			gapLimit,
+			func(addr string) bool { return addr == address(0) || addr == address(2) },
+			func() {
+				RegisterOpen(suite.mockWallet)
+				for i := range keys {
+					suite.registerDeriveAtIndex(keys[i], i)
+				}
+			},
+			true,
+			[]int{0, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			infos, err := suite.ledger.DiscoverAccounts(suite.hrp, tc.gapLimit, tc.isUsed)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Len(infos, len(tc.expIndices))
+			for i, index := range tc.expIndices {
+				suite.Require().Equal(address(index), infos[i].Address)
+			}
+		})
+	}
+}
+
+// registerDeriveAtIndex registers the derivation of the key at the given address
+// index of the default base derivation path.
+func (suite *LedgerTestSuite) registerDeriveAtIndex(key *ecdsa.PrivateKey, index int) {
+	path := make(gethaccounts.DerivationPath, len(gethaccounts.DefaultBaseDerivationPath))
+	copy(path, gethaccounts.DefaultBaseDerivationPath)
+	path[len(path)-1] += uint32(index)
+	RegisterDeriveForPath(suite.mockWallet, path, crypto.PubkeyToAddress(key.PublicKey), &key.PublicKey)
+}
+
+func (suite *LedgerTestSuite) TestGetAddresses() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	testCases := []struct {
+		name     string
+		opts     []ledger.Option
+		mockFunc func()
+		expHRP   string
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			nil,
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			"",
+			false,
+		},
+		{
+			"fail - configured HRP not allowed",
+			[]ledger.Option{ledger.WithHRP("cosmos"), ledger.WithAllowedHRPs("evmos")},
+			func() {},
+			"",
+			false,
+		},
+		{
+			"pass - default HRP",
+			nil,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			"evmos",
+			true,
+		},
+		{
+			"pass - configured HRP",
+			[]ledger.Option{ledger.WithHRP("cosmos")},
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			"cosmos",
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			for _, opt := range tc.opts {
+				opt(suite.ledger)
+			}
+			tc.mockFunc()
+
+			hexAddr, bech32Addr, pubKey, err := suite.ledger.GetAddresses(gethaccounts.DefaultBaseDerivationPath)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			expBech32, err := sdk.Bech32ifyAddressBytes(tc.expHRP, addr.Bytes())
+			suite.Require().NoError(err)
+
+			suite.Require().Equal(addr.Hex(), hexAddr)
+			suite.Require().Equal(expBech32, bech32Addr)
+			suite.Require().Equal(crypto.FromECDSAPub(&privKey.PublicKey), pubKey)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestHexAddressChecksummed() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			false,
+		},
+		{
+			"pass - EIP-55 checksummed address",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+
+			hexAddr, err := suite.ledger.HexAddressChecksummed(gethaccounts.DefaultBaseDerivationPath)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(addr.Hex(), hexAddr)
+			suite.Require().True(common.IsHexAddress(hexAddr))
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ledgerModels maps the model identifiers encoded in the USB product IDs to the
+// Ledger model names.
+var ledgerModels = map[uint16]string{
+	0x0: "Blue",
+	0x1: "Nano S",
+	0x4: "Nano X",
+	0x5: "Nano S Plus",
+	0x6: "Stax",
+}
+
+// DeviceInfo describes the connected Ledger, e.g. to attach the user's setup to a
+// bug report.
+type DeviceInfo struct {
+	Model      string `json:"model"`      // Ledger model, e.g. "Nano X"
+	Firmware   string `json:"firmware"`   // Device release number reported over USB, e.g. "2.01"
+	AppName    string `json:"appName"`    // Name of the running app, if reported by the device
+	AppVersion string `json:"appVersion"` // Version of the Ethereum app, e.g. "1.10.2"
+	URL        string `json:"url"`        // Canonical URL of the wallet
+	Path       string `json:"path"`       // Platform-specific path of the USB device
+	VendorID   uint16 `json:"vendorId"`   // USB vendor identifier
+	ProductID  uint16 `json:"productId"`  // USB product identifier
+	Serial     string `json:"serial"`     // Serial number reported over USB, if any
+}
+
+// String returns a one-line description of the device.
+func (d DeviceInfo) String() string {
+	appName := d.AppName
+	if appName == "" {
+		appName = ethereumAppName
+	}
+
+	return fmt.Sprintf("Ledger %s (firmware %s, product 0x%04x) running %s app v%s at %s",
+		d.Model, d.Firmware, d.ProductID, appName, d.AppVersion, d.Path)
+}
+
+// DeviceInfo returns the model, firmware and app version as well as the USB path of
+// the primary wallet, as reported by the USB descriptor and the Ethereum app.
+func (e *EvmosSECP256K1) DeviceInfo() (DeviceInfo, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return DeviceInfo{}, errors.New("could not get Ledger device info: no wallet found")
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return DeviceInfo{}, err
+	}
+
+	config, err := e.PrimaryWallet.AppConfiguration()
+	if err != nil {
+		return DeviceInfo{}, fmt.Errorf("unable to get Ledger app version, please open the Ethereum app and retry: %w", err)
+	}
+
+	// Older firmwares don't report the running app, in which case the name is left empty
+	var appName string
+	if app, err := e.PrimaryWallet.RunningApp(); err == nil {
+		appName = app.Name
+	}
+
+	usbInfo := e.PrimaryWallet.Info()
+
+	return DeviceInfo{
+		Model:      ledgerModel(usbInfo.ProductID),
+		Firmware:   fmt.Sprintf("%x.%02x", usbInfo.Release>>8, usbInfo.Release&0xff),
+		AppName:    appName,
+		AppVersion: fmt.Sprintf("%d.%d.%d", config.Version[0], config.Version[1], config.Version[2]),
+		URL:        e.PrimaryWallet.URL().String(),
+		Path:       usbInfo.Path,
+		VendorID:   usbInfo.VendorID,
+		ProductID:  usbInfo.ProductID,
+		Serial:     usbInfo.Serial,
+	}, nil
+}
+
+// placeholderSerial is the serial number reported by the Ledger firmwares which
+// don't provide a unique one, and thus doesn't identify a device.
+const placeholderSerial = "0001"
+
+// DeviceID returns the unique identifier of the primary wallet reported by its USB
+// descriptor, i.e. its serial number, so that callers can pin a trusted device and
+// reject any other one, e.g. by comparing it with WalletInfo.Serial in a device
+// filter (see WithDeviceFilter). ErrDeviceIDUnavailable is returned if the device
+// doesn't report a serial number, or reports a placeholder shared by all devices.
+// No request is sent to the device.
+func (e *EvmosSECP256K1) DeviceID() (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return "", errors.New("could not get Ledger device ID: no wallet found")
+	}
+
+	serial := e.PrimaryWallet.Info().Serial
+	if serial == "" || serial == placeholderSerial {
+		return "", fmt.Errorf("%w: serial number %q reported by device", ErrDeviceIDUnavailable, serial)
+	}
+
+	return serial, nil
+}
+
+// ledgerModel returns the name of the Ledger model identified by the USB product ID.
+func ledgerModel(productID uint16) string {
+	var id uint16
+	switch {
+	case productID < 0x0010:
+		// Original product IDs
+		id = productID
+	case productID < 0x0100:
+		// HID + WebUSB product IDs of the Ledger Blue, e.g. 0x0015
+		id = 0
+	default:
+		// HID + WebUSB product IDs encode the model in the upper nibble, e.g. 0x4015
+		id = productID >> 12
+	}
+
+	if model, ok := ledgerModels[id]; ok {
+		return model
+	}
+	return fmt.Sprintf("unknown (0x%04x)", productID)
+}
@@ -0,0 +1,207 @@
+package ledger_test
+
+import (
+	"encoding/json"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestDeviceInfo() {
+	usbInfo := accounts.DeviceInfo{
+		Path:         "/dev/hidraw0",
+		VendorID:     0x2c97,
+		ProductID:    0x4015,
+		Release:      0x0201,
+		Manufacturer: "Ledger",
+		Product:      "Nano X",
+	}
+	url := gethaccounts.URL{Scheme: "ledger", Path: "/dev/hidraw0"}
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expInfo  ledger.DeviceInfo
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			ledger.DeviceInfo{},
+			false,
+		},
+		{
+			"fail - Ethereum app not open",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfigurationError(suite.mockWallet, 0x6e00)
+			},
+			ledger.DeviceInfo{},
+			false,
+		},
+		{
+			"pass - running app not reported",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 10, 2}})
+				RegisterRunningAppError(suite.mockWallet, 0x6d00)
+				RegisterInfo(suite.mockWallet, usbInfo)
+				RegisterURL(suite.mockWallet, url)
+			},
+			ledger.DeviceInfo{
+				Model:      "Nano X",
+				Firmware:   "2.01",
+				AppVersion: "1.10.2",
+				URL:        url.String(),
+				Path:       "/dev/hidraw0",
+				VendorID:   0x2c97,
+				ProductID:  0x4015,
+			},
+			true,
+		},
+		{
+			"pass - device info",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 10, 2}})
+				RegisterRunningApp(suite.mockWallet, "Ethereum")
+				RegisterInfo(suite.mockWallet, usbInfo)
+				RegisterURL(suite.mockWallet, url)
+			},
+			ledger.DeviceInfo{
+				Model:      "Nano X",
+				Firmware:   "2.01",
+				AppName:    "Ethereum",
+				AppVersion: "1.10.2",
+				URL:        url.String(),
+				Path:       "/dev/hidraw0",
+				VendorID:   0x2c97,
+				ProductID:  0x4015,
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			info, err := suite.ledger.DeviceInfo()
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expInfo, info)
+			suite.Require().Equal("Ledger Nano X (firmware 2.01, product 0x4015) running Ethereum app v1.10.2 at /dev/hidraw0", info.String())
+
+			bz, err := json.Marshal(info)
+			suite.Require().NoError(err)
+			suite.Require().Contains(string(bz), `"model":"Nano X"`)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestDeviceModel() {
+	testCases := []struct {
+		productID uint16
+		expModel  string
+	}{
+		{0x0001, "Nano S"},
+		{0x1015, "Nano S"},
+		{0x0004, "Nano X"},
+		{0x4011, "Nano X"},
+		{0x5015, "Nano S Plus"},
+		{0x6015, "Stax"},
+		{0x0015, "Blue"},
+		{0x9015, "unknown (0x9015)"},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.expModel, func() {
+			suite.SetupTest() // reset
+			RegisterOpen(suite.mockWallet)
+			RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 10, 2}})
+			RegisterRunningApp(suite.mockWallet, "Ethereum")
+			RegisterInfo(suite.mockWallet, accounts.DeviceInfo{ProductID: tc.productID})
+			RegisterURL(suite.mockWallet, gethaccounts.URL{Scheme: "ledger"})
+
+			info, err := suite.ledger.DeviceInfo()
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expModel, info.Model)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestDeviceID() {
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expID    string
+		expErr   error
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			"",
+			nil,
+			false,
+		},
+		{
+			"fail - no serial number reported",
+			func() {
+				RegisterInfo(suite.mockWallet, accounts.DeviceInfo{ProductID: 0x4015})
+			},
+			"",
+			ledger.ErrDeviceIDUnavailable,
+			false,
+		},
+		{
+			"fail - placeholder serial number reported",
+			func() {
+				RegisterInfo(suite.mockWallet, accounts.DeviceInfo{ProductID: 0x4015, Serial: "0001"})
+			},
+			"",
+			ledger.ErrDeviceIDUnavailable,
+			false,
+		},
+		{
+			"pass - serial number reported",
+			func() {
+				RegisterInfo(suite.mockWallet, accounts.DeviceInfo{ProductID: 0x4015, Serial: "4a1f0c7d"})
+			},
+			"4a1f0c7d",
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			id, err := suite.ledger.DeviceID()
+			if !tc.expPass {
+				suite.Require().Error(err)
+				if tc.expErr != nil {
+					suite.Require().ErrorIs(err, tc.expErr)
+				}
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expID, id)
+
+			// The device is identified without any request
+			suite.mockWallet.AssertNotCalled(suite.T(), "Open", "")
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/evmos/evmos-ledger-go/usbwallet"
+)
+
+// maxFullDisplayDepth and maxFullDisplayFields bound the complexity of the EIP-712
+// messages the Ethereum app is expected to render field by field: the deepest
+// nesting of structs and arrays below the primary type, and the number of fields of
+// the structs the message uses. They are conservative estimates of the memory
+// available to the app, not limits it reports.
+const (
+	maxFullDisplayDepth  = 5
+	maxFullDisplayFields = 64
+)
+
+// DisplayMode defines how EIP-712 messages are presented on the Ledger before the
+// user signs them.
+type DisplayMode int
+
+const (
+	// DisplayHashOnly sends the domain and message hashes only, which the Ledger
+	// displays instead of the message. This is the default, and is supported by
+	// every version of the Ethereum app.
+	DisplayHashOnly DisplayMode = iota
+	// DisplayFull sends the whole message, which the Ledger displays field by field.
+	// It requires an Ethereum app supporting it (see SupportsEIP712FullDisplay),
+	// and falls back to DisplayHashOnly otherwise.
+	DisplayFull
+)
+
+// String implements fmt.Stringer.
+func (m DisplayMode) String() string {
+	switch m {
+	case DisplayHashOnly:
+		return "hash-only"
+	case DisplayFull:
+		return "full"
+	default:
+		return "unknown"
+	}
+}
+
+// SetDisplayMode sets how EIP-712 messages are presented on the Ledger. With
+// DisplayFull, the app version is checked before each signing request, and devices
+// that can't render the whole message are sent the hashes only, as with
+// DisplayHashOnly.
+func (e *EvmosSECP256K1) SetDisplayMode(mode DisplayMode) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.displayMode = mode
+}
+
+// fullDisplay reports whether the whole EIP-712 message should be sent to the
+// device, according to the display mode and the capabilities of the running app.
+//
+// Note, fullDisplay assumes the lock is held!
+func (e *EvmosSECP256K1) fullDisplay() bool {
+	if e.displayMode != DisplayFull {
+		return false
+	}
+
+	supported, err := e.supportsEIP712FullDisplay()
+	switch {
+	case err != nil:
+		e.log().Debugf("Unable to check EIP-712 full display support, sending hashes only: %v", err)
+		return false
+	case !supported:
+		e.log().Debugf("Ethereum app does not support EIP-712 full display, sending hashes only")
+		return false
+	}
+
+	return true
+}
+
+// WillDisplayFully predicts whether the Ledger will display the fields of the typed
+// data when signing it, or only its hashes, so that UIs can warn users before they
+// commit to signing. The fields are displayed only with DisplayFull, an Ethereum app
+// supporting it (see SupportsEIP712FullDisplay), and a message simple enough for the
+// app to render, which is estimated from the nesting depth and the number of fields
+// of its types. The estimate is a heuristic, so the device may still fall back to
+// the hashes of a message predicted to be displayed fully.
+func (e *EvmosSECP256K1) WillDisplayFully(typedData apitypes.TypedData) (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return false, errors.New("could not check EIP-712 display: no wallet found")
+	}
+
+	if _, ok := typedData.Types[typedData.PrimaryType]; !ok {
+		return false, fmt.Errorf("could not check EIP-712 display: primary type %q is not defined", typedData.PrimaryType)
+	}
+
+	if e.displayMode != DisplayFull {
+		return false, nil
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return false, err
+	}
+
+	supported, err := e.supportsEIP712FullDisplay()
+	if err != nil || !supported {
+		return false, err
+	}
+
+	depth, fields := typedDataComplexity(typedData)
+	return depth <= maxFullDisplayDepth && fields <= maxFullDisplayFields, nil
+}
+
+// typedDataComplexity returns the deepest nesting of structs and arrays below the
+// primary type of the typed data, and the number of fields of the structs it uses,
+// including the domain. The walk stops past maxFullDisplayDepth, so recursive types
+// are reported as too deep.
+func typedDataComplexity(typedData apitypes.TypedData) (depth, fields int) {
+	visited := make(map[string]bool)
+
+	var walk func(name string, level int) int
+	walk = func(name string, level int) int {
+		if level > maxFullDisplayDepth {
+			return level
+		}
+		if !visited[name] {
+			visited[name] = true
+			fields += len(typedData.Types[name])
+		}
+
+		deepest := level
+		for _, field := range typedData.Types[name] {
+			typ, fieldLevel := field.Type, level
+			for i := strings.LastIndex(typ, "["); i > 0 && strings.HasSuffix(typ, "]"); i = strings.LastIndex(typ, "[") {
+				typ = typ[:i]
+				fieldLevel++
+			}
+			if _, ok := typedData.Types[typ]; ok {
+				fieldLevel = walk(typ, fieldLevel+1)
+			}
+			if fieldLevel > deepest {
+				deepest = fieldLevel
+			}
+		}
+		return deepest
+	}
+
+	depth = walk(typedData.PrimaryType, 0)
+	if !visited["EIP712Domain"] {
+		fields += len(typedData.Types["EIP712Domain"])
+	}
+	return depth, fields
+}
+
+// DisplayPage is one screen of an EIP-712 message displayed field by field, as
+// returned by DisplayPages.
+type DisplayPage struct {
+	Field string `json:"field"` // Name of the field, as displayed by the Ledger
+	Path  string `json:"path"`  // Full path of the field, e.g. "fee.amount.0.denom"
+	Value string `json:"value"` // Part of the value displayed on this page
+	Page  int    `json:"page"`  // Position of the page among the pages of the field, from 1
+	Pages int    `json:"pages"` // Number of pages of the field
+}
+
+// Title returns the title of the page, i.e. the name of the field followed by the
+// position of the page if the value spans multiple pages (e.g. "memo (1/3)").
+func (p DisplayPage) Title() string {
+	if p.Pages <= 1 {
+		return p.Field
+	}
+	return fmt.Sprintf("%s (%d/%d)", p.Field, p.Page, p.Pages)
+}
+
+// DisplayPages flattens the typed data into the ordered list of pages scrolled
+// through when the Ledger displays it field by field (see DisplayFull), so that a
+// host UI can mirror the device screen for cross-verification: the fields of the
+// domain come first, followed by the fields of the message in the order of their
+// type definitions, recursing into structs and arrays. Values are formatted as the
+// Ethereum app does: integers in decimal, addresses with their EIP-55 checksum and
+// bytes in hex. If pageChars is positive, values longer than pageChars characters
+// are split over multiple pages. It doesn't communicate with the device.
+func DisplayPages(typedData apitypes.TypedData, pageChars int) ([]DisplayPage, error) {
+	if _, ok := typedData.Types[typedData.PrimaryType]; !ok {
+		return nil, fmt.Errorf("primary type %q is not defined", typedData.PrimaryType)
+	}
+
+	var pages []DisplayPage
+	add := func(field, path, value string) {
+		chunks := splitDisplayValue(value, pageChars)
+		for i, chunk := range chunks {
+			pages = append(pages, DisplayPage{Field: field, Path: path, Value: chunk, Page: i + 1, Pages: len(chunks)})
+		}
+	}
+
+	var walk func(typeName, path string, data map[string]interface{}) error
+	var walkValue func(field, typ, path string, value interface{}) error
+
+	walk = func(typeName, path string, data map[string]interface{}) error {
+		for _, field := range typedData.Types[typeName] {
+			value, ok := data[field.Name]
+			if !ok {
+				// The domain fields are optional
+				if typeName == "EIP712Domain" {
+					continue
+				}
+				return fmt.Errorf("missing value for field %s", joinDisplayPath(path, field.Name))
+			}
+			if err := walkValue(field.Name, field.Type, joinDisplayPath(path, field.Name), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	walkValue = func(field, typ, path string, value interface{}) error {
+		if i := strings.LastIndex(typ, "["); i > 0 && strings.HasSuffix(typ, "]") {
+			items, ok := value.([]interface{})
+			if !ok {
+				return fmt.Errorf("invalid value for array field %s: %T", path, value)
+			}
+			for j, item := range items {
+				if err := walkValue(field, typ[:i], joinDisplayPath(path, strconv.Itoa(j)), item); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if _, ok := typedData.Types[typ]; ok {
+			data, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("invalid value for struct field %s: %T", path, value)
+			}
+			return walk(typ, path, data)
+		}
+
+		formatted, err := formatDisplayValue(typ, value)
+		if err != nil {
+			return fmt.Errorf("invalid value for field %s: %w", path, err)
+		}
+		add(field, path, formatted)
+		return nil
+	}
+
+	if err := walk("EIP712Domain", "", typedData.Domain.Map()); err != nil {
+		return nil, err
+	}
+	if err := walk(typedData.PrimaryType, "", typedData.Message); err != nil {
+		return nil, err
+	}
+
+	return pages, nil
+}
+
+// formatDisplayValue formats the value of an atomic EIP-712 type as the Ethereum
+// app displays it.
+func formatDisplayValue(typ string, value interface{}) (string, error) {
+	switch {
+	case typ == "address":
+		str, ok := value.(string)
+		if !ok || !common.IsHexAddress(str) {
+			return "", fmt.Errorf("invalid address: %v", value)
+		}
+		return common.HexToAddress(str).Hex(), nil
+	case typ == "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return "", fmt.Errorf("invalid bool: %v", value)
+		}
+		return strconv.FormatBool(b), nil
+	case strings.HasPrefix(typ, "bytes"):
+		switch v := value.(type) {
+		case []byte:
+			return hexutil.Encode(v), nil
+		case hexutil.Bytes:
+			return hexutil.Encode(v), nil
+		case string:
+			bz, err := hexutil.Decode(v)
+			if err != nil {
+				return "", fmt.Errorf("invalid bytes: %w", err)
+			}
+			return hexutil.Encode(bz), nil
+		default:
+			return "", fmt.Errorf("invalid bytes: %T", value)
+		}
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		// Parsed like when signing, so the pages match what the device displays
+		n, err := usbwallet.EIP712Integer(value)
+		if err != nil {
+			return "", err
+		}
+		return n.String(), nil
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
+
+// joinDisplayPath appends the field name or array index to the path of a field.
+func joinDisplayPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// splitDisplayValue splits the value into chunks of at most pageChars characters,
+// or returns it as a single chunk if pageChars is not positive.
+func splitDisplayValue(value string, pageChars int) []string {
+	runes := []rune(value)
+	if pageChars <= 0 || len(runes) <= pageChars {
+		return []string{value}
+	}
+
+	chunks := make([]string, 0, (len(runes)+pageChars-1)/pageChars)
+	for len(runes) > 0 {
+		n := pageChars
+		if len(runes) < n {
+			n = len(runes)
+		}
+		chunks = append(chunks, string(runes[:n]))
+		runes = runes[n:]
+	}
+	return chunks
+}
@@ -0,0 +1,340 @@
+package ledger_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos/v14/ethereum/eip712"
+)
+
+func (suite *LedgerTestSuite) TestDisplayMode() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	testCases := []struct {
+		name     string
+		mode     ledger.DisplayMode
+		mockFunc func()
+	}{
+		{
+			"pass - hash-only by default",
+			ledger.DisplayHashOnly,
+			func() {
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+		},
+		{
+			"pass - full display supported",
+			ledger.DisplayFull,
+			func() {
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: ledger.DefaultEIP712FullDisplayVersion})
+				RegisterSignTypedDataFull(suite.mockWallet, account, suite.txAmino, nil)
+			},
+		},
+		{
+			"pass - full display unsupported, fall back to hash-only",
+			ledger.DisplayFull,
+			func() {
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 9, 18}})
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+		},
+		{
+			"pass - app version unknown, fall back to hash-only",
+			ledger.DisplayFull,
+			func() {
+				RegisterAppConfigurationError(suite.mockWallet, 0x6d00)
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			ledger.WithDisplayMode(tc.mode)(suite.ledger)
+			RegisterOpen(suite.mockWallet)
+			RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			tc.mockFunc()
+
+			signature, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+			suite.Require().NoError(err)
+			suite.Require().Len(signature, crypto.SignatureLength)
+			suite.mockWallet.AssertExpectations(suite.T())
+		})
+	}
+}
+
+// nestedTypedData returns typed data whose primary type nests the given number of
+// structs, each with the given number of fields.
+func nestedTypedData(depth, fields int) apitypes.TypedData {
+	types := apitypes.Types{
+		"EIP712Domain": {{Name: "name", Type: "string"}},
+	}
+	for i := 0; i <= depth; i++ {
+		var structFields []apitypes.Type
+		for j := 0; j < fields; j++ {
+			structFields = append(structFields, apitypes.Type{Name: fmt.Sprintf("field%d", j), Type: "uint256"})
+		}
+		if i < depth {
+			structFields = append(structFields, apitypes.Type{Name: "inner", Type: fmt.Sprintf("Level%d", i+1)})
+		}
+		types[fmt.Sprintf("Level%d", i)] = structFields
+	}
+
+	return apitypes.TypedData{Types: types, PrimaryType: "Level0"}
+}
+
+func (suite *LedgerTestSuite) TestWillDisplayFully() {
+	typedData, err := eip712.GetEIP712TypedDataForMsg(suite.txAmino)
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name      string
+		mode      ledger.DisplayMode
+		typedData apitypes.TypedData
+		mockFunc  func()
+		expPass   bool
+		expFull   bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			ledger.DisplayFull,
+			typedData,
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+			false,
+		},
+		{
+			"fail - primary type not defined",
+			ledger.DisplayFull,
+			apitypes.TypedData{PrimaryType: "Tx"},
+			func() {},
+			false,
+			false,
+		},
+		{
+			"fail - app version unknown",
+			ledger.DisplayFull,
+			typedData,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfigurationError(suite.mockWallet, 0x6d00)
+			},
+			false,
+			false,
+		},
+		{
+			"pass - hash-only display mode",
+			ledger.DisplayHashOnly,
+			typedData,
+			func() {},
+			true,
+			false,
+		},
+		{
+			"pass - full display unsupported by the app",
+			ledger.DisplayFull,
+			typedData,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 9, 18}})
+			},
+			true,
+			false,
+		},
+		{
+			"pass - message displayed fully",
+			ledger.DisplayFull,
+			typedData,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: ledger.DefaultEIP712FullDisplayVersion})
+			},
+			true,
+			true,
+		},
+		{
+			"pass - message nested too deeply",
+			ledger.DisplayFull,
+			nestedTypedData(6, 1),
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: ledger.DefaultEIP712FullDisplayVersion})
+			},
+			true,
+			false,
+		},
+		{
+			"pass - message with too many fields",
+			ledger.DisplayFull,
+			nestedTypedData(1, 40),
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: ledger.DefaultEIP712FullDisplayVersion})
+			},
+			true,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			ledger.WithDisplayMode(tc.mode)(suite.ledger)
+			tc.mockFunc()
+
+			full, err := suite.ledger.WillDisplayFully(tc.typedData)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expFull, full)
+			suite.mockWallet.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestDisplayPages() {
+	typedData, err := eip712.GetEIP712TypedDataForMsg(suite.txAmino)
+	suite.Require().NoError(err)
+
+	pages, err := ledger.DisplayPages(typedData, 0)
+	suite.Require().NoError(err)
+
+	// The domain comes first, then the message in the order of the type definitions
+	expPaths := []string{
+		"name", "version", "chainId", "verifyingContract", "salt",
+		"account_number", "chain_id", "fee.amount.0.denom", "fee.amount.0.amount", "fee.gas", "memo", "sequence",
+		"msg0.value.to_address", "msg0.value.from_address", "msg0.value.amount.0.denom", "msg0.value.amount.0.amount", "msg0.type",
+	}
+	paths := make([]string, len(pages))
+	for i, page := range pages {
+		paths[i] = page.Path
+	}
+	suite.Require().Equal(expPaths, paths)
+
+	suite.Require().Equal(ledger.DisplayPage{Field: "chainId", Path: "chainId", Value: "9000", Page: 1, Pages: 1}, pages[2])
+	suite.Require().Equal("denom", pages[7].Title())
+	suite.Require().Equal("cosmos10t8ca2w09ykd6ph0agdz5stvgau47whhaggl9a", pages[12].Value)
+
+	// Long values are split over multiple pages
+	pages, err = ledger.DisplayPages(typedData, 20)
+	suite.Require().NoError(err)
+
+	var toAddress []ledger.DisplayPage
+	for _, page := range pages {
+		if page.Path == "msg0.value.to_address" {
+			toAddress = append(toAddress, page)
+		}
+	}
+	suite.Require().Len(toAddress, 3)
+	suite.Require().Equal("to_address (1/3)", toAddress[0].Title())
+	suite.Require().Equal("cosmos10t8ca2w09ykd6", toAddress[0].Value)
+	suite.Require().Equal("cosmos10t8ca2w09ykd6ph0agdz5stvgau47whhaggl9a", toAddress[0].Value+toAddress[1].Value+toAddress[2].Value)
+}
+
+func (suite *LedgerTestSuite) TestDisplayPagesFormatting() {
+	types := apitypes.Types{
+		"EIP712Domain": {{Name: "name", Type: "string"}},
+		"Mail": {
+			{Name: "to", Type: "address"},
+			{Name: "amount", Type: "uint256"},
+			{Name: "urgent", Type: "bool"},
+			{Name: "data", Type: "bytes"},
+			{Name: "delta", Type: "int256"},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		message   apitypes.TypedDataMessage
+		expValues []string
+		expPass   bool
+	}{
+		{
+			"pass - atomic types",
+			apitypes.TypedDataMessage{
+				"to":     "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+				"amount": "0x10",
+				"urgent": true,
+				"data":   "0xCAFE",
+				"delta":  "-5",
+			},
+			[]string{"Ether Mail", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "16", "true", "0xcafe", "-5"},
+			true,
+		},
+		{
+			"pass - JSON numbers",
+			apitypes.TypedDataMessage{
+				"to":     "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+				"amount": json.Number("16"),
+				"urgent": true,
+				"data":   "0x",
+				"delta":  json.Number("-5"),
+			},
+			[]string{"Ether Mail", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "16", "true", "0x", "-5"},
+			true,
+		},
+		{
+			"fail - missing field",
+			apitypes.TypedDataMessage{
+				"to":     "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+				"amount": "16",
+				"urgent": true,
+			},
+			nil,
+			false,
+		},
+		{
+			"fail - invalid address",
+			apitypes.TypedDataMessage{
+				"to":     "evmos1invalid",
+				"amount": "16",
+				"urgent": true,
+				"data":   "0x",
+			},
+			nil,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			typedData := apitypes.TypedData{
+				Types:       types,
+				PrimaryType: "Mail",
+				Domain:      apitypes.TypedDataDomain{Name: "Ether Mail"},
+				Message:     tc.message,
+			}
+
+			pages, err := ledger.DisplayPages(typedData, 0)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			values := make([]string, len(pages))
+			for i, page := range pages {
+				values[i] = page.Value
+			}
+			suite.Require().Equal(tc.expValues, values)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package ledger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// ErrDomainMismatch is returned when a field of the EIP-712 domain to sign differs
+// from the expected one (see SetExpectedDomain).
+var ErrDomainMismatch = errors.New("EIP-712 domain mismatch")
+
+// expectedDomain holds the expected fields of EIP-712 domains, where the zero value
+// of a field leaves it unchecked.
+type expectedDomain struct {
+	name              string
+	version           string
+	chainID           *big.Int
+	verifyingContract *common.Address
+	salt              []byte
+}
+
+// SetExpectedDomain makes every EIP-712 signing request check the fields of the
+// typed data domain against the given ones, and fail with ErrDomainMismatch naming
+// the offending field otherwise, which prevents signing a message crafted for
+// another application or chain. Empty strings and nil values leave the respective
+// fields unchecked, and passing only those disables the check, which is the
+// default. The salt is compared with the hex-decoded domain salt if it has the 0x
+// prefix, and with its raw bytes otherwise.
+func (e *EvmosSECP256K1) SetExpectedDomain(name, version string, chainID *big.Int, verifyingContract *common.Address, salt []byte) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if name == "" && version == "" && chainID == nil && verifyingContract == nil && salt == nil {
+		e.expectedDomain = nil
+		return
+	}
+
+	domain := &expectedDomain{
+		name:    name,
+		version: version,
+	}
+	if chainID != nil {
+		domain.chainID = new(big.Int).Set(chainID)
+	}
+	if verifyingContract != nil {
+		contract := *verifyingContract
+		domain.verifyingContract = &contract
+	}
+	if salt != nil {
+		domain.salt = append([]byte{}, salt...)
+	}
+
+	e.expectedDomain = domain
+}
+
+// verifyDomain checks the typed data domain against the expected one, if any.
+//
+// Note, verifyDomain assumes the lock is held!
+func (e *EvmosSECP256K1) verifyDomain(typedData apitypes.TypedData) error {
+	expected := e.expectedDomain
+	if expected == nil {
+		return nil
+	}
+
+	domain := typedData.Domain
+
+	if expected.name != "" && domain.Name != expected.name {
+		return fmt.Errorf("%w: domain has name %q, expected %q", ErrDomainMismatch, domain.Name, expected.name)
+	}
+
+	if expected.version != "" && domain.Version != expected.version {
+		return fmt.Errorf("%w: domain has version %q, expected %q", ErrDomainMismatch, domain.Version, expected.version)
+	}
+
+	if expected.chainID != nil {
+		if domain.ChainId == nil {
+			return fmt.Errorf("%w: domain has no chain ID, expected %s", ErrDomainMismatch, expected.chainID)
+		}
+		if chainID := (*big.Int)(domain.ChainId); chainID.Cmp(expected.chainID) != 0 {
+			return fmt.Errorf("%w: domain has chain ID %s, expected %s", ErrDomainMismatch, chainID, expected.chainID)
+		}
+	}
+
+	if expected.verifyingContract != nil {
+		if !common.IsHexAddress(domain.VerifyingContract) || common.HexToAddress(domain.VerifyingContract) != *expected.verifyingContract {
+			return fmt.Errorf("%w: domain has verifying contract %q, expected %s", ErrDomainMismatch, domain.VerifyingContract, expected.verifyingContract)
+		}
+	}
+
+	if expected.salt != nil {
+		salt := []byte(domain.Salt)
+		if strings.HasPrefix(domain.Salt, "0x") {
+			decoded, err := hexutil.Decode(domain.Salt)
+			if err != nil {
+				return fmt.Errorf("%w: domain has invalid salt %q: %w", ErrDomainMismatch, domain.Salt, err)
+			}
+			salt = decoded
+		}
+		if !bytes.Equal(salt, expected.salt) {
+			return fmt.Errorf("%w: domain has salt %q, expected %#x", ErrDomainMismatch, domain.Salt, expected.salt)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package ledger_test
+
+import (
+	"math/big"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos/v14/ethereum/eip712"
+)
+
+func (suite *LedgerTestSuite) TestExpectedDomain() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	contract := common.HexToAddress("0x15C3Eb3B621d1Bff62CbA1c9536B7c1AE9149b57")
+	otherContract := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	salt := common.HexToHash("0x01").Bytes()
+
+	// Domain of suite.txAmino: "Cosmos Web3", "1.0.0", 9000, "cosmos", "0"
+	evmosTypedData, err := eip712.GetEIP712TypedDataForMsg(suite.txAmino)
+	suite.Require().NoError(err)
+
+	contractTypedData := evmosTypedData
+	contractTypedData.Domain.VerifyingContract = contract.Hex()
+	contractTypedData.Domain.Salt = common.BytesToHash(salt).Hex()
+
+	testCases := []struct {
+		name        string
+		typedData   apitypes.TypedData
+		opt         ledger.Option
+		expPass     bool
+		expErrField string
+	}{
+		{
+			"pass - domain not checked",
+			evmosTypedData,
+			ledger.WithExpectedDomain("", "", nil, nil, nil),
+			true,
+			"",
+		},
+		{
+			"pass - domain of the sign doc",
+			evmosTypedData,
+			ledger.WithExpectedDomain("Cosmos Web3", "1.0.0", big.NewInt(9000), nil, []byte("0")),
+			true,
+			"",
+		},
+		{
+			"pass - verifying contract and hex salt",
+			contractTypedData,
+			ledger.WithExpectedDomain("", "", nil, &contract, salt),
+			true,
+			"",
+		},
+		{
+			"fail - name mismatch",
+			evmosTypedData,
+			ledger.WithExpectedDomain("Other App", "", nil, nil, nil),
+			false,
+			"name",
+		},
+		{
+			"fail - version mismatch",
+			evmosTypedData,
+			ledger.WithExpectedDomain("", "2.0.0", nil, nil, nil),
+			false,
+			"version",
+		},
+		{
+			"fail - chain ID mismatch",
+			evmosTypedData,
+			ledger.WithExpectedDomain("", "", big.NewInt(9001), nil, nil),
+			false,
+			"chain ID",
+		},
+		{
+			"fail - verifying contract is not an address",
+			evmosTypedData,
+			ledger.WithExpectedDomain("", "", nil, &contract, nil),
+			false,
+			"verifying contract",
+		},
+		{
+			"fail - verifying contract mismatch",
+			contractTypedData,
+			ledger.WithExpectedDomain("", "", nil, &otherContract, nil),
+			false,
+			"verifying contract",
+		},
+		{
+			"fail - salt mismatch",
+			contractTypedData,
+			ledger.WithExpectedDomain("", "", nil, nil, []byte{0x02}),
+			false,
+			"salt",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.opt(suite.ledger)
+			RegisterOpen(suite.mockWallet)
+			RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			RegisterSignTypedDataObject(suite.mockWallet, account, tc.typedData)
+
+			_, err := suite.ledger.SignTypedData(gethaccounts.DefaultBaseDerivationPath, tc.typedData)
+			if !tc.expPass {
+				suite.Require().ErrorIs(err, ledger.ErrDomainMismatch)
+				suite.Require().Contains(err.Error(), tc.expErrField)
+				suite.mockWallet.AssertNotCalled(suite.T(), "SignTypedData", account, mock.Anything)
+				return
+			}
+
+			suite.Require().NoError(err)
+		})
+	}
+}
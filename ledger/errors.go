@@ -0,0 +1,91 @@
+package ledger
+
+import (
+	"errors"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+
+	"github.com/evmos/evmos-ledger-go/usbwallet"
+)
+
+// Errors returned by the Ledger device, which can be matched using errors.Is.
+var (
+	// ErrUserRejected is returned when the user declines a request on the device.
+	ErrUserRejected = usbwallet.ErrUserRejected
+
+	// ErrDeviceLocked is returned when the device is locked and must be unlocked with the PIN.
+	ErrDeviceLocked = usbwallet.ErrDeviceLocked
+
+	// ErrNoDevice is returned when connecting while no Ledger is plugged in. A device
+	// that is plugged in but locked is reported with ErrDeviceLocked instead.
+	ErrNoDevice = errors.New("no hardware wallets detected")
+
+	// ErrAppNotOpen is returned when no app is running on the device.
+	ErrAppNotOpen = usbwallet.ErrAppNotOpen
+
+	// ErrWrongApp is returned when an app other than the Ethereum app is running on the device.
+	ErrWrongApp = usbwallet.ErrWrongApp
+
+	// ErrAppNotInstalled is returned by RequestOpenApp when the requested app is not installed on the device.
+	ErrAppNotInstalled = usbwallet.ErrAppNotInstalled
+
+	// ErrInvalidData is returned when the app rejects the data of a request.
+	ErrInvalidData = usbwallet.ErrInvalidData
+
+	// ErrBlindSigningDisabled is returned when a request requiring blind signing (e.g.
+	// an EIP-712 message signed from its hashes) is rejected because blind signing is
+	// disabled in the settings of the Ethereum app (see BlindSigningEnabled).
+	ErrBlindSigningDisabled = errors.New("blind signing is disabled, please enable it in the Ethereum app settings and retry")
+
+	// ErrWalletClosed is returned when the primary wallet is closed while automatic
+	// reopening is disabled (see SetAutoReopen).
+	ErrWalletClosed = gethaccounts.ErrWalletClosed
+
+	// ErrDeviceBusy is returned when the device is held by another application, such as Ledger Live.
+	ErrDeviceBusy = usbwallet.ErrDeviceBusy
+
+	// ErrDeviceOpen is returned when the device cannot be opened for a reason other
+	// than being held by another application, e.g. because it was unplugged. Unlike
+	// ErrDeviceBusy, requests failing with it are not retried.
+	ErrDeviceOpen = usbwallet.ErrDeviceOpen
+
+	// ErrDevicePermission is returned when the user lacks the permissions to access
+	// the device, which on Linux means that the Ledger udev rules are missing.
+	ErrDevicePermission = usbwallet.ErrDevicePermission
+
+	// ErrShortResponse is returned when a reply of the device is truncated, e.g.
+	// because it was unplugged in the middle of a request.
+	ErrShortResponse = usbwallet.ErrShortResponse
+
+	// ErrRefreshPending is returned by RefreshDevices when the devices cannot be scanned
+	// because a request is awaiting confirmation on a Ledger.
+	ErrRefreshPending = usbwallet.ErrRefreshPending
+
+	// ErrCommandTimeout is returned when the device doesn't complete a command, such
+	// as a derivation or a signing request, within the command timeout (see
+	// SetCommandTimeout).
+	ErrCommandTimeout = usbwallet.ErrCommandTimeout
+
+	// ErrDetectionTimeout is returned when the hardware wallets could not be detected
+	// within the configured connect timeout.
+	ErrDetectionTimeout = errors.New("timed out detecting hardware wallets")
+
+	// ErrCloseTimeout is returned by Close when the device doesn't release the
+	// connection within the close timeout (see SetCloseTimeout).
+	ErrCloseTimeout = errors.New("timed out closing the Ledger")
+
+	// ErrAborted is returned by a signing request cancelled by AbortCurrentOperation.
+	ErrAborted = errors.New("ledger operation aborted")
+
+	// ErrMissingPublicKey is returned when the device answers a derivation request
+	// without a public key, which happens with some versions of the Ethereum app.
+	ErrMissingPublicKey = errors.New("ledger returned no public key")
+
+	// ErrDeviceIDUnavailable is returned by DeviceID when the device doesn't report a
+	// unique identifier.
+	ErrDeviceIDUnavailable = errors.New("ledger device doesn't report a unique identifier")
+
+	// ErrAddressMismatch is returned by VerifyAddress when the address derived by the
+	// device differs from the expected one.
+	ErrAddressMismatch = errors.New("ledger address mismatch")
+)
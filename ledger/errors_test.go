@@ -0,0 +1,81 @@
+package ledger_test
+
+import (
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos-ledger-go/usbwallet"
+)
+
+func (suite *LedgerTestSuite) TestDeriveErrors() {
+	testCases := []struct {
+		name       string
+		statusWord uint16
+		expErr     error
+	}{
+		{"device locked", 0x5515, ledger.ErrDeviceLocked},
+		{"device locked - older firmware", 0x6982, ledger.ErrDeviceLocked},
+		{"app not open", 0x6d00, ledger.ErrAppNotOpen},
+		{"dashboard open", 0x6511, ledger.ErrAppNotOpen},
+		{"dashboard open - unknown instruction", 0x6d02, ledger.ErrAppNotOpen},
+		{"dashboard open - unknown class", 0x6e01, ledger.ErrAppNotOpen},
+		{"wrong app open", 0x6e00, ledger.ErrWrongApp},
+		{"invalid data", 0x6a80, ledger.ErrInvalidData},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			RegisterOpen(suite.mockWallet)
+			RegisterDeriveAPDUError(suite.mockWallet, tc.statusWord)
+			_, _, err := suite.ledger.GetAddressPubKeySECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.hrp)
+			suite.Require().ErrorIs(err, tc.expErr)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestDeriveShortResponse() {
+	RegisterOpen(suite.mockWallet)
+	RegisterDeriveShortResponse(suite.mockWallet)
+
+	_, _, err := suite.ledger.GetAddressPubKeySECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.hrp)
+	suite.Require().ErrorIs(err, ledger.ErrShortResponse)
+}
+
+func (suite *LedgerTestSuite) TestDeriveErrorHDPath() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+
+	failedPath := gethaccounts.DerivationPath{0x80000000 + 44, 0x80000000 + 60, 0x80000000 + 0, 0, 2}
+
+	RegisterOpen(suite.mockWallet)
+	suite.registerDeriveAtIndex(privKey, 0)
+	suite.registerDeriveAtIndex(privKey, 1)
+	suite.mockWallet.On("Derive", failedPath, true).
+		Return(accounts.Account{}, &usbwallet.APDUError{StatusWord: 0x5515})
+
+	// The failing account is identified, and the sentinel is preserved
+	_, err = suite.ledger.DeriveAccounts(gethaccounts.DefaultBaseDerivationPath, 3, suite.hrp)
+	suite.Require().ErrorIs(err, ledger.ErrDeviceLocked)
+	suite.Require().ErrorContains(err, "derive m/44'/60'/0'/0/2")
+}
+
+func (suite *LedgerTestSuite) TestSignatureRejected() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	RegisterOpen(suite.mockWallet)
+	RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+	RegisterSignTypedDataRejected(suite.mockWallet, account, suite.txAmino)
+
+	_, err = suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+	suite.Require().ErrorIs(err, ledger.ErrUserRejected)
+	suite.Require().ErrorContains(err, "sign m/44'/60'/0'/0/0")
+}
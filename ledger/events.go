@@ -0,0 +1,102 @@
+package ledger
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+)
+
+// WalletEventType describes a change in the hardware wallets attached to the machine.
+type WalletEventType string
+
+const (
+	// WalletConnected is emitted when a Ledger is plugged in.
+	WalletConnected WalletEventType = "connected"
+
+	// WalletDisconnected is emitted when a Ledger is unplugged or fails.
+	WalletDisconnected WalletEventType = "disconnected"
+)
+
+// WalletEvent notifies subscribers that a hardware wallet was connected or removed.
+type WalletEvent struct {
+	URL  string          `json:"url"`  // Canonical URL of the wallet, e.g. "ledger://0001:0004:00"
+	Type WalletEventType `json:"type"` // Kind of change that happened
+}
+
+// Subscribe delivers a WalletEvent on sink whenever the hub detects that a Ledger
+// was connected or removed, so that UIs can react to device changes without
+// polling. The devices are scanned periodically for as long as at least one
+// subscription is active. Events are delivered in order, and the scan waits for
+// slow subscribers, so sink should be drained promptly. The subscription must be
+// released with Unsubscribe.
+func (e *EvmosSECP256K1) Subscribe(sink chan<- WalletEvent) (event.Subscription, error) {
+	if e.Hub == nil {
+		return nil, errors.New("could not subscribe to Ledger events: no hardware wallet hub found")
+	}
+
+	hubEvents := make(chan accounts.WalletEvent)
+	hubSub := e.Hub.Subscribe(hubEvents)
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer hubSub.Unsubscribe()
+
+		for {
+			select {
+			case hubEvent := <-hubEvents:
+				walletEvent, ok := newWalletEvent(hubEvent)
+				if !ok {
+					continue
+				}
+
+				select {
+				case sink <- walletEvent:
+				case <-quit:
+					return nil
+				}
+			case err := <-hubSub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// SetRefreshInterval sets the time between the scans of the USB devices performed
+// while subscriptions are active (see Subscribe), which bounds how quickly Ledgers
+// being plugged in or unplugged are detected. Latency-sensitive applications can
+// poll faster, while battery-conscious ones can poll slower. The interval also
+// applies to the hubs instantiated when reconnecting. A non-positive interval
+// restores the default of one second.
+func (e *EvmosSECP256K1) SetRefreshInterval(interval time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.refreshInterval = interval
+	if e.Hub != nil {
+		e.Hub.SetRefreshInterval(interval)
+	}
+}
+
+// newWalletEvent converts an event fired by the hub into a WalletEvent. False is
+// returned for event kinds that are not forwarded to subscribers.
+func newWalletEvent(hubEvent accounts.WalletEvent) (WalletEvent, bool) {
+	var eventType WalletEventType
+
+	switch hubEvent.Kind {
+	case accounts.WalletArrived:
+		eventType = WalletConnected
+	case accounts.WalletDropped:
+		eventType = WalletDisconnected
+	default:
+		return WalletEvent{}, false
+	}
+
+	return WalletEvent{
+		URL:  hubEvent.Wallet.URL().String(),
+		Type: eventType,
+	}, true
+}
@@ -0,0 +1,128 @@
+package ledger_test
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos-ledger-go/usbwallet"
+)
+
+// pluggableTransport is a transport whose devices can be plugged in while the hub
+// scans them.
+type pluggableTransport struct {
+	mu      sync.Mutex
+	devices []usbwallet.DeviceInfo
+}
+
+func (t *pluggableTransport) Enumerate(uint16) ([]usbwallet.DeviceInfo, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]usbwallet.DeviceInfo{}, t.devices...), nil
+}
+
+func (t *pluggableTransport) Open(usbwallet.DeviceInfo) (io.ReadWriteCloser, error) {
+	return nil, errBridgeUnreachable
+}
+
+func (t *pluggableTransport) plug(device usbwallet.DeviceInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.devices = append(t.devices, device)
+}
+
+func (suite *LedgerTestSuite) TestSubscribe() {
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - no hardware wallet hub",
+			func() {
+				suite.ledger.Hub = nil
+			},
+			false,
+		},
+		{
+			"pass - subscribed to the hub",
+			func() {},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+
+			events := make(chan ledger.WalletEvent)
+			sub, err := suite.ledger.Subscribe(events)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+
+			// No device is plugged in or removed during the test
+			select {
+			case ev := <-events:
+				suite.Failf("unexpected event", "%+v", ev)
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			sub.Unsubscribe()
+
+			_, open := <-sub.Err()
+			suite.Require().False(open, "the error channel must be closed once unsubscribed")
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestSetRefreshInterval() {
+	testCases := []struct {
+		name     string
+		interval time.Duration
+		expPass  bool
+	}{
+		{
+			"fail - default interval doesn't detect the device in time",
+			0,
+			false,
+		},
+		{
+			"pass - short interval detects the device in time",
+			20 * time.Millisecond,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			transport := &pluggableTransport{}
+			hub, err := usbwallet.NewLedgerHubWithTransport(transport)
+			suite.Require().NoError(err)
+			suite.ledger.Hub = hub
+			suite.ledger.SetRefreshInterval(tc.interval)
+
+			events := make(chan ledger.WalletEvent)
+			sub, err := suite.ledger.Subscribe(events)
+			suite.Require().NoError(err)
+			defer sub.Unsubscribe()
+
+			transport.plug(usbwallet.DeviceInfo{Path: "tcp://127.0.0.1:9999", ProductID: 0x4015})
+
+			select {
+			case ev := <-events:
+				suite.Require().True(tc.expPass, "unexpected event %+v", ev)
+				suite.Require().Equal(ledger.WalletConnected, ev.Type)
+			case <-time.After(500 * time.Millisecond):
+				suite.Require().False(tc.expPass, "device not detected")
+			}
+		})
+	}
+}
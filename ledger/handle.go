@@ -0,0 +1,79 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// AccountHandle is bound to a fixed HD path of an EvmosSECP256K1, and proxies its
+// requests without taking the path as an argument. Handles share the connection
+// and lock of the EvmosSECP256K1 they are created from, so that a multi-account
+// application can use one handle per account over a single device connection.
+type AccountHandle struct {
+	ledger *EvmosSECP256K1
+	hdPath []uint32
+}
+
+// ForPath returns a handle bound to the given HD path, which is validated against
+// the configured coin type. The path is copied, so that later changes by the caller
+// don't affect the handle.
+func (e *EvmosSECP256K1) ForPath(hdPath []uint32) (*AccountHandle, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.validateHDPath(hdPath); err != nil {
+		return nil, err
+	}
+
+	return &AccountHandle{
+		ledger: e,
+		hdPath: append([]uint32(nil), hdPath...),
+	}, nil
+}
+
+// Ledger returns the EvmosSECP256K1 the handle was created from.
+func (h *AccountHandle) Ledger() *EvmosSECP256K1 {
+	return h.ledger
+}
+
+// HDPath returns a copy of the HD path the handle is bound to.
+func (h *AccountHandle) HDPath() []uint32 {
+	return append([]uint32(nil), h.hdPath...)
+}
+
+// GetPublicKey returns the uncompressed public key of the account (see
+// EvmosSECP256K1.GetPublicKeySECP256K1).
+func (h *AccountHandle) GetPublicKey() ([]byte, error) {
+	return h.ledger.GetPublicKeySECP256K1(h.hdPath)
+}
+
+// GetAddressPubKey returns the uncompressed public key of the account along with
+// its Bech32 address using the given HRP (see EvmosSECP256K1.GetAddressPubKeySECP256K1).
+func (h *AccountHandle) GetAddressPubKey(hrp string) ([]byte, string, error) {
+	return h.ledger.GetAddressPubKeySECP256K1(h.hdPath, hrp)
+}
+
+// Sign signs the sign doc with the account using EIP-712 (see
+// EvmosSECP256K1.SignSECP256K1).
+func (h *AccountHandle) Sign(signDocBytes []byte) ([]byte, error) {
+	return h.ledger.SignSECP256K1(h.hdPath, signDocBytes)
+}
+
+// SignWithContext behaves like Sign, but stops waiting for the device once the
+// provided context is done (see EvmosSECP256K1.SignSECP256K1WithContext).
+func (h *AccountHandle) SignWithContext(ctx context.Context, signDocBytes []byte) ([]byte, error) {
+	return h.ledger.SignSECP256K1WithContext(ctx, h.hdPath, signDocBytes)
+}
+
+// SignTypedData signs the typed data with the account (see
+// EvmosSECP256K1.SignTypedData).
+func (h *AccountHandle) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	return h.ledger.SignTypedData(h.hdPath, typedData)
+}
+
+// SignPersonalMessage signs the message with the account following the EIP-191
+// personal_sign scheme (see EvmosSECP256K1.SignPersonalMessage).
+func (h *AccountHandle) SignPersonalMessage(message []byte) ([]byte, error) {
+	return h.ledger.SignPersonalMessage(h.hdPath, message)
+}
@@ -0,0 +1,99 @@
+package ledger_test
+
+import (
+	"crypto/ecdsa"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestForPath() {
+	testCases := []struct {
+		name    string
+		hdPath  []uint32
+		expPass bool
+	}{
+		{
+			"fail - too short",
+			[]uint32{0x80000000 + 44, 0x80000000 + 60, 0x80000000 + 0, 0},
+			false,
+		},
+		{
+			"fail - unexpected coin type",
+			[]uint32{0x80000000 + 44, 0x80000000 + 118, 0x80000000 + 0, 0, 0},
+			false,
+		},
+		{
+			"pass - default path",
+			gethaccounts.DefaultBaseDerivationPath,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+
+			handle, err := suite.ledger.ForPath(tc.hdPath)
+			if !tc.expPass {
+				suite.Require().ErrorIs(err, ledger.ErrInvalidHDPath)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(suite.ledger, handle.Ledger())
+			suite.Require().Equal(tc.hdPath, handle.HDPath())
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestAccountHandles() {
+	paths := []gethaccounts.DerivationPath{
+		{0x80000000 + 44, 0x80000000 + 60, 0x80000000 + 0, 0, 0},
+		{0x80000000 + 44, 0x80000000 + 60, 0x80000000 + 0, 0, 1},
+	}
+
+	keys := make([]*ecdsa.PrivateKey, len(paths))
+	handles := make([]*ledger.AccountHandle, len(paths))
+	for i, path := range paths {
+		key, err := crypto.GenerateKey()
+		suite.Require().NoError(err)
+		keys[i] = key
+
+		hdPath := append([]uint32(nil), path...)
+		handles[i], err = suite.ledger.ForPath(hdPath)
+		suite.Require().NoError(err)
+
+		// Changing the path of the caller doesn't affect the handle
+		hdPath[4] = 42
+		suite.Require().Equal([]uint32(path), handles[i].HDPath())
+	}
+
+	RegisterOpen(suite.mockWallet)
+	for i, path := range paths {
+		addr := crypto.PubkeyToAddress(keys[i].PublicKey)
+		RegisterDeriveForPath(suite.mockWallet, path, addr, &keys[i].PublicKey)
+		RegisterSignTypedData(suite.mockWallet, accounts.Account{Address: addr, PublicKey: &keys[i].PublicKey}, suite.txAmino)
+	}
+
+	for i, handle := range handles {
+		pubKey, err := handle.GetPublicKey()
+		suite.Require().NoError(err)
+		suite.Require().Equal(crypto.FromECDSAPub(&keys[i].PublicKey), pubKey)
+
+		_, addr, err := handle.GetAddressPubKey(suite.hrp)
+		suite.Require().NoError(err)
+		expAddr, err := ledger.FormatBech32(crypto.PubkeyToAddress(keys[i].PublicKey).Bytes(), suite.hrp)
+		suite.Require().NoError(err)
+		suite.Require().Equal(expAddr, addr)
+
+		signature, err := handle.Sign(suite.txAmino)
+		suite.Require().NoError(err)
+		suite.Require().Len(signature, crypto.SignatureLength)
+	}
+
+	suite.mockWallet.AssertExpectations(suite.T())
+}
@@ -0,0 +1,232 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+)
+
+const (
+	// bip44PathLength is the number of components of a BIP-44 path:
+	// m / purpose' / coin_type' / account' / change / address_index
+	bip44PathLength = 5
+
+	// bip44Purpose is the purpose component of BIP-44 paths.
+	bip44Purpose = 44
+
+	// ethereumCoinType is the SLIP-44 coin type of Ethereum, used by Evmos accounts.
+	ethereumCoinType = 60
+
+	// CosmosCoinType is the SLIP-44 coin type of the Cosmos Hub, which some Evmos-based
+	// chains and the keys migrated from Cosmos SDK keyrings use (see
+	// SetAllowedCoinTypes).
+	CosmosCoinType = 118
+)
+
+// ErrInvalidHDPath is returned when an HD path is not a valid BIP-44 path for the
+// configured coin type.
+var ErrInvalidHDPath = errors.New("invalid HD path")
+
+// ParseHDPath parses a BIP-44 HD path such as "m/44'/60'/0'/0/0" into its
+// components, which can be used with the other methods of EvmosSECP256K1.
+func ParseHDPath(path string) ([]uint32, error) {
+	hdPath, err := gethaccounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHDPath, err)
+	}
+
+	if err := validateBIP44Path(hdPath); err != nil {
+		return nil, err
+	}
+
+	return hdPath, nil
+}
+
+// SetCoinType sets the SLIP-44 coin type expected in the HD paths passed to the
+// device. HD paths using another coin type are rejected before reaching the device.
+// It defaults to 60 (Ethereum), and should only be overridden for chains deriving
+// their keys with another coin type.
+func (e *EvmosSECP256K1) SetCoinType(coinType uint32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.coinType = &coinType
+}
+
+// SetAllowedCoinTypes accepts HD paths using any of the given SLIP-44 coin types, in
+// addition to the one set with SetCoinType, which remains the coin type of the
+// paths built by the package (e.g. by DiscoverAccounts). For instance, allowing
+// CosmosCoinType lets keys migrated from a Cosmos SDK keyring, which are rooted at
+// m/44'/118', be used alongside the Ethereum ones. The public keys and addresses
+// are derived the same way regardless of the coin type, although the Ethereum app
+// may reject paths it isn't permitted to derive. Calling it without arguments only
+// accepts the coin type set with SetCoinType, which is the default.
+func (e *EvmosSECP256K1) SetAllowedCoinTypes(coinTypes ...uint32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(coinTypes) == 0 {
+		e.allowedCoinTypes = nil
+		return
+	}
+
+	e.allowedCoinTypes = make(map[uint32]struct{}, len(coinTypes))
+	for _, coinType := range coinTypes {
+		e.allowedCoinTypes[coinType] = struct{}{}
+	}
+}
+
+// pathTemplateIndex is the placeholder of the index in HD path templates.
+const pathTemplateIndex = "{index}"
+
+// SetPathTemplate sets the HD path template used by PathForIndex and DeriveByIndex.
+// The template is a BIP-44 path using the configured coin type, in which exactly one
+// component is the "{index}" placeholder, which can be hardened, e.g.
+// "m/44'/60'/0'/0/{index}" or "m/44'/60'/{index}'/0/0". ErrInvalidHDPath is returned
+// if the template is malformed, in which case the previous template is kept.
+func (e *EvmosSECP256K1) SetPathTemplate(template string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	components := strings.Split(template, "/")
+	position := -1
+	for i, component := range components {
+		if strings.TrimSuffix(component, "'") != pathTemplateIndex {
+			continue
+		}
+		if position != -1 {
+			return fmt.Errorf("%w: template %q has more than one %s placeholder", ErrInvalidHDPath, template, pathTemplateIndex)
+		}
+		position = i
+	}
+
+	if position == -1 {
+		return fmt.Errorf("%w: template %q has no %s placeholder", ErrInvalidHDPath, template, pathTemplateIndex)
+	}
+
+	hdPath, err := ParseHDPath(strings.Replace(template, pathTemplateIndex, "0", 1))
+	if err != nil {
+		return err
+	}
+
+	if err := e.validateHDPath(hdPath); err != nil {
+		return err
+	}
+
+	// Locate the placeholder in the parsed path, which excludes the "m" root or is
+	// prefixed with the default root (m/44'/60'/0'/0) if the template is relative
+	position += len(hdPath) - len(components)
+
+	e.pathTemplate = hdPath
+	e.pathIndex = position
+
+	return nil
+}
+
+// PathForIndex returns the HD path obtained by replacing the placeholder of the
+// template set with SetPathTemplate by the given index.
+func (e *EvmosSECP256K1) PathForIndex(index uint32) ([]uint32, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.pathForIndex(index)
+}
+
+// DeriveByIndex derives the account at the HD path obtained by replacing the
+// placeholder of the template set with SetPathTemplate by the given index (see
+// DeriveAccount).
+func (e *EvmosSECP256K1) DeriveByIndex(index uint32) (accounts.Account, error) {
+	hdPath, err := e.PathForIndex(index)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+
+	return e.DeriveAccount(hdPath)
+}
+
+// pathForIndex implements PathForIndex.
+//
+// Note, pathForIndex assumes the lock is held!
+func (e *EvmosSECP256K1) pathForIndex(index uint32) ([]uint32, error) {
+	if e.pathTemplate == nil {
+		return nil, errors.New("could not build HD path: no path template set")
+	}
+
+	if index >= hardenedOffset {
+		return nil, fmt.Errorf("%w: index %d overflows into the hardened range", ErrInvalidHDPath, index)
+	}
+
+	hdPath := make([]uint32, len(e.pathTemplate))
+	copy(hdPath, e.pathTemplate)
+	hdPath[e.pathIndex] += index
+
+	return hdPath, nil
+}
+
+// validateHDPath checks that the HD path is a BIP-44 path using an allowed coin
+// type. The purpose, coin type and account components can either be hardened
+// or not, since they are always hardened before deriving.
+//
+// Note, validateHDPath assumes the lock is held!
+func (e *EvmosSECP256K1) validateHDPath(hdPath []uint32) error {
+	if err := validateBIP44Path(hdPath); err != nil {
+		return err
+	}
+
+	return e.validateCoinType(hdPath)
+}
+
+// validateCoinType checks that the coin type of the BIP-44 path is the configured
+// one or one of the allowed ones.
+//
+// Note, validateCoinType assumes the lock is held!
+func (e *EvmosSECP256K1) validateCoinType(hdPath []uint32) error {
+	coinType := unhardened(hdPath[1])
+	if coinType == e.expectedCoinType() {
+		return nil
+	}
+
+	if _, ok := e.allowedCoinTypes[coinType]; ok {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s uses coin type %d instead of %d", ErrInvalidHDPath,
+		gethaccounts.DerivationPath(hdPath), coinType, e.expectedCoinType())
+}
+
+// expectedCoinType returns the configured coin type, or the Ethereum one if unset.
+//
+// Note, expectedCoinType assumes the lock is held!
+func (e *EvmosSECP256K1) expectedCoinType() uint32 {
+	if e.coinType != nil {
+		return *e.coinType
+	}
+	return ethereumCoinType
+}
+
+// validateBIP44Path checks that the HD path has the BIP-44 form.
+func validateBIP44Path(hdPath []uint32) error {
+	if len(hdPath) != bip44PathLength {
+		return fmt.Errorf("%w: %s has %d components instead of %d", ErrInvalidHDPath,
+			gethaccounts.DerivationPath(hdPath), len(hdPath), bip44PathLength)
+	}
+
+	if unhardened(hdPath[0]) != bip44Purpose {
+		return fmt.Errorf("%w: %s uses purpose %d instead of %d", ErrInvalidHDPath,
+			gethaccounts.DerivationPath(hdPath), unhardened(hdPath[0]), bip44Purpose)
+	}
+
+	return nil
+}
+
+// unhardened returns the index of the HD path component, without the hardened offset.
+func unhardened(component uint32) uint32 {
+	if component >= hardenedOffset {
+		return component - hardenedOffset
+	}
+	return component
+}
@@ -0,0 +1,251 @@
+package ledger_test
+
+import (
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestParseHDPath() {
+	testCases := []struct {
+		name    string
+		path    string
+		expPath []uint32
+		expPass bool
+	}{
+		{
+			"pass - Ethereum path",
+			"m/44'/60'/0'/0/0",
+			gethaccounts.DefaultBaseDerivationPath,
+			true,
+		},
+		{
+			"pass - Cosmos path",
+			"m/44'/118'/0'/0/3",
+			[]uint32{0x80000000 + 44, 0x80000000 + 118, 0x80000000, 0, 3},
+			true,
+		},
+		{
+			"fail - malformed path",
+			"m/44'/sixty'/0'/0/0",
+			nil,
+			false,
+		},
+		{
+			"fail - wrong length",
+			"m/44'/60'/0'",
+			nil,
+			false,
+		},
+		{
+			"fail - wrong purpose",
+			"m/49'/60'/0'/0/0",
+			nil,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			hdPath, err := ledger.ParseHDPath(tc.path)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expPath, hdPath)
+			} else {
+				suite.Require().ErrorIs(err, ledger.ErrInvalidHDPath)
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestHDPathValidation() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	cosmosPath := []uint32{0x80000000 + 44, 0x80000000 + 118, 0x80000000, 0, 0}
+
+	testCases := []struct {
+		name     string
+		hdPath   []uint32
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - 3-component path",
+			[]uint32{44, 60, 0},
+			func() {
+				RegisterOpen(suite.mockWallet)
+			},
+			false,
+		},
+		{
+			"fail - non-Ethereum coin type",
+			cosmosPath,
+			func() {
+				RegisterOpen(suite.mockWallet)
+			},
+			false,
+		},
+		{
+			"pass - unhardened Cosmos SDK path",
+			[]uint32{44, 60, 0, 0, 0},
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveForPath(suite.mockWallet, []uint32{44, 60, 0, 0, 0}, addr, &privKey.PublicKey)
+			},
+			true,
+		},
+		{
+			"pass - coin type overridden",
+			cosmosPath,
+			func() {
+				suite.ledger.SetCoinType(118)
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveForPath(suite.mockWallet, cosmosPath, addr, &privKey.PublicKey)
+			},
+			true,
+		},
+		{
+			"pass - Cosmos coin type allowed",
+			cosmosPath,
+			func() {
+				suite.ledger.SetAllowedCoinTypes(ledger.CosmosCoinType)
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveForPath(suite.mockWallet, cosmosPath, addr, &privKey.PublicKey)
+			},
+			true,
+		},
+		{
+			"pass - Ethereum coin type accepted along with the allowed ones",
+			gethaccounts.DefaultBaseDerivationPath,
+			func() {
+				suite.ledger.SetAllowedCoinTypes(ledger.CosmosCoinType)
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveForPath(suite.mockWallet, gethaccounts.DefaultBaseDerivationPath, addr, &privKey.PublicKey)
+			},
+			true,
+		},
+		{
+			"fail - coin type not allowed",
+			[]uint32{0x80000000 + 44, 0x80000000 + 1, 0x80000000, 0, 0},
+			func() {
+				suite.ledger.SetAllowedCoinTypes(ledger.CosmosCoinType)
+				RegisterOpen(suite.mockWallet)
+			},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			_, err := suite.ledger.GetPublicKeySECP256K1(tc.hdPath)
+			if tc.expPass {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().ErrorIs(err, ledger.ErrInvalidHDPath)
+				suite.mockWallet.AssertNotCalled(suite.T(), "Derive", tc.hdPath, true)
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestPathTemplate() {
+	testCases := []struct {
+		name     string
+		template string
+		index    uint32
+		expPath  []uint32
+		expPass  bool
+	}{
+		{
+			"pass - address index",
+			"m/44'/60'/0'/0/{index}",
+			7,
+			[]uint32{0x80000000 + 44, 0x80000000 + 60, 0x80000000, 0, 7},
+			true,
+		},
+		{
+			"pass - hardened account index",
+			"m/44'/60'/{index}'/0/0",
+			2,
+			[]uint32{0x80000000 + 44, 0x80000000 + 60, 0x80000000 + 2, 0, 0},
+			true,
+		},
+		{
+			"pass - relative template",
+			"{index}",
+			1,
+			[]uint32{0x80000000 + 44, 0x80000000 + 60, 0x80000000, 0, 1},
+			true,
+		},
+		{
+			"fail - no placeholder",
+			"m/44'/60'/0'/0/0",
+			0,
+			nil,
+			false,
+		},
+		{
+			"fail - several placeholders",
+			"m/44'/60'/{index}'/0/{index}",
+			0,
+			nil,
+			false,
+		},
+		{
+			"fail - not a BIP-44 path",
+			"m/44'/60'/0'/{index}",
+			0,
+			nil,
+			false,
+		},
+		{
+			"fail - wrong coin type",
+			"m/44'/118'/0'/0/{index}",
+			0,
+			nil,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			err := suite.ledger.SetPathTemplate(tc.template)
+			if !tc.expPass {
+				suite.Require().ErrorIs(err, ledger.ErrInvalidHDPath)
+
+				_, err = suite.ledger.PathForIndex(tc.index)
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			hdPath, err := suite.ledger.PathForIndex(tc.index)
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expPath, hdPath)
+
+			_, err = suite.ledger.PathForIndex(0x80000000)
+			suite.Require().ErrorIs(err, ledger.ErrInvalidHDPath)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestDeriveByIndex() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	path := []uint32{0x80000000 + 44, 0x80000000 + 60, 0x80000000, 0, 3}
+	RegisterOpen(suite.mockWallet)
+	RegisterDeriveForPath(suite.mockWallet, path, addr, &privKey.PublicKey)
+
+	suite.Require().NoError(suite.ledger.SetPathTemplate("m/44'/60'/0'/0/{index}"))
+	account, err := suite.ledger.DeriveByIndex(3)
+	suite.Require().NoError(err)
+	suite.Require().Equal(addr, account.Address)
+}
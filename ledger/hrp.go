@@ -0,0 +1,168 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const (
+	// maxHRPLength is the maximum length of a bech32 human-readable prefix, as defined in BIP-173.
+	maxHRPLength = 83
+
+	// defaultHRP is the human-readable prefix used by GetAddresses unless another one is set.
+	defaultHRP = "evmos"
+)
+
+// ErrInvalidHRP is returned when the human-readable prefix passed to
+// GetAddressPubKeySECP256K1 is malformed or not allowed.
+var ErrInvalidHRP = errors.New("invalid bech32 human-readable prefix")
+
+// SetAllowedHRPs restricts the human-readable prefixes accepted by
+// GetAddressPubKeySECP256K1 to the given set (e.g. "evmos"), which catches
+// misconfigured chain prefixes before an address is encoded with them. Calling it
+// without arguments accepts any well-formed prefix, which is the default.
+func (e *EvmosSECP256K1) SetAllowedHRPs(hrps ...string) {
+	e.hrpMu.Lock()
+	defer e.hrpMu.Unlock()
+
+	if len(hrps) == 0 {
+		e.allowedHRPs = nil
+		return
+	}
+
+	e.allowedHRPs = make(map[string]struct{}, len(hrps))
+	for _, hrp := range hrps {
+		e.allowedHRPs[hrp] = struct{}{}
+	}
+}
+
+// SetHRP sets the human-readable prefix used to encode the bech32 addresses
+// returned by GetAddresses. It defaults to "evmos".
+func (e *EvmosSECP256K1) SetHRP(hrp string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.hrp = hrp
+}
+
+// RegisterHRP registers the human-readable prefix of the addresses of the given
+// EIP-155 chain ID (e.g. 9001 for "evmos"), so that the prefixes stay consistent
+// with the network being signed for:
+//   - if the chain ID is the expected one (see SetExpectedChainID), addresses must be
+//     encoded with the prefix, which is also the default of GetAddresses unless
+//     another one is set with SetHRP;
+//   - EIP-712 messages whose domain carries the chain ID are only signed if the
+//     prefix set with SetHRP, if any, is the registered one.
+//
+// Registering an empty prefix removes the one of the chain ID.
+func (e *EvmosSECP256K1) RegisterHRP(chainID *big.Int, hrp string) {
+	if chainID == nil {
+		return
+	}
+
+	e.hrpMu.Lock()
+	defer e.hrpMu.Unlock()
+
+	if hrp == "" {
+		delete(e.chainHRPs, chainID.String())
+		return
+	}
+
+	if e.chainHRPs == nil {
+		e.chainHRPs = make(map[string]string)
+	}
+	e.chainHRPs[chainID.String()] = hrp
+}
+
+// HRPForChainID returns the human-readable prefix registered for the given chain ID
+// with RegisterHRP, if any.
+func (e *EvmosSECP256K1) HRPForChainID(chainID *big.Int) (string, bool) {
+	if chainID == nil {
+		return "", false
+	}
+
+	e.hrpMu.RLock()
+	defer e.hrpMu.RUnlock()
+
+	hrp, ok := e.chainHRPs[chainID.String()]
+	return hrp, ok
+}
+
+// getHRP returns the configured human-readable prefix, or the one registered for
+// the expected chain ID, or the default one if neither is set.
+//
+// Note, getHRP assumes the lock is held!
+func (e *EvmosSECP256K1) getHRP() string {
+	if e.hrp != "" {
+		return e.hrp
+	}
+
+	e.hrpMu.RLock()
+	defer e.hrpMu.RUnlock()
+
+	if hrp, ok := e.chainHRPs[e.hrpChainID]; ok {
+		return hrp
+	}
+	return defaultHRP
+}
+
+// verifyHRP checks that the configured human-readable prefix, if any, is the one
+// registered for the chain ID of the typed data domain, if any.
+//
+// Note, verifyHRP assumes the lock is held!
+func (e *EvmosSECP256K1) verifyHRP(typedData apitypes.TypedData) error {
+	if e.hrp == "" || typedData.Domain.ChainId == nil {
+		return nil
+	}
+
+	chainID := (*big.Int)(typedData.Domain.ChainId)
+	hrp, ok := e.HRPForChainID(chainID)
+	if !ok || hrp == e.hrp {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q is not the prefix %q of chain ID %s", ErrInvalidHRP, e.hrp, hrp, chainID)
+}
+
+// validateHRP checks that the human-readable prefix is well-formed according to
+// BIP-173 and, if a set of allowed prefixes is configured, that it belongs to it.
+// Unlike most helpers, it doesn't require the lock, so that requests waiting for a
+// shared derivation (see sharedDerive) are validated without queueing.
+func (e *EvmosSECP256K1) validateHRP(hrp string) error {
+	if hrp == "" {
+		return fmt.Errorf("%w: prefix is empty", ErrInvalidHRP)
+	}
+
+	if len(hrp) > maxHRPLength {
+		return fmt.Errorf("%w: %q exceeds %d characters", ErrInvalidHRP, hrp, maxHRPLength)
+	}
+
+	for _, c := range hrp {
+		if c < 33 || c > 126 {
+			return fmt.Errorf("%w: %q contains invalid character %q", ErrInvalidHRP, hrp, c)
+		}
+	}
+
+	if strings.ToLower(hrp) != hrp && strings.ToUpper(hrp) != hrp {
+		return fmt.Errorf("%w: %q mixes upper and lower case", ErrInvalidHRP, hrp)
+	}
+
+	e.hrpMu.RLock()
+	defer e.hrpMu.RUnlock()
+
+	if e.allowedHRPs != nil {
+		if _, ok := e.allowedHRPs[hrp]; !ok {
+			return fmt.Errorf("%w: %q is not an allowed prefix", ErrInvalidHRP, hrp)
+		}
+	}
+
+	if chainHRP, ok := e.chainHRPs[e.hrpChainID]; ok && hrp != chainHRP {
+		return fmt.Errorf("%w: %q is not the prefix %q of chain ID %s", ErrInvalidHRP, hrp, chainHRP, e.hrpChainID)
+	}
+
+	return nil
+}
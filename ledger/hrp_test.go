@@ -0,0 +1,145 @@
+package ledger_test
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestHRPForChainID() {
+	suite.ledger.RegisterHRP(big.NewInt(9001), "evmos")
+
+	hrp, ok := suite.ledger.HRPForChainID(big.NewInt(9001))
+	suite.Require().True(ok)
+	suite.Require().Equal("evmos", hrp)
+
+	_, ok = suite.ledger.HRPForChainID(big.NewInt(9000))
+	suite.Require().False(ok)
+
+	// Registering an empty prefix removes it
+	suite.ledger.RegisterHRP(big.NewInt(9001), "")
+	_, ok = suite.ledger.HRPForChainID(big.NewInt(9001))
+	suite.Require().False(ok)
+}
+
+func (suite *LedgerTestSuite) TestRegisterHRPAddresses() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	testCases := []struct {
+		name    string
+		opts    []ledger.Option
+		hrp     string
+		expHRP  string
+		expPass bool
+	}{
+		{
+			"pass - prefix of the expected chain ID",
+			[]ledger.Option{ledger.WithRegisteredHRP(big.NewInt(9000), "cosmos"), ledger.WithExpectedChainID(big.NewInt(9000))},
+			"cosmos",
+			"cosmos",
+			true,
+		},
+		{
+			"pass - prefix registered for another chain ID",
+			[]ledger.Option{ledger.WithRegisteredHRP(big.NewInt(9001), "cosmos"), ledger.WithExpectedChainID(big.NewInt(9000))},
+			"evmos",
+			"evmos",
+			true,
+		},
+		{
+			"fail - prefix mismatching the expected chain ID",
+			[]ledger.Option{ledger.WithRegisteredHRP(big.NewInt(9000), "cosmos"), ledger.WithExpectedChainID(big.NewInt(9000))},
+			"evmos",
+			"",
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			for _, opt := range tc.opts {
+				opt(suite.ledger)
+			}
+			RegisterOpen(suite.mockWallet)
+			RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+
+			_, bech32Addr, err := suite.ledger.GetAddressPubKeySECP256K1(gethaccounts.DefaultBaseDerivationPath, tc.hrp)
+			if !tc.expPass {
+				suite.Require().ErrorIs(err, ledger.ErrInvalidHRP)
+				return
+			}
+			suite.Require().NoError(err)
+
+			expAddr, err := sdk.Bech32ifyAddressBytes(tc.expHRP, addr.Bytes())
+			suite.Require().NoError(err)
+			suite.Require().Equal(expAddr, bech32Addr)
+
+			// The default prefix of GetAddresses is the one of the expected chain ID
+			_, bech32Addr, _, err = suite.ledger.GetAddresses(gethaccounts.DefaultBaseDerivationPath)
+			suite.Require().NoError(err)
+			suite.Require().Equal(expAddr, bech32Addr)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestRegisterHRPSigning() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	testCases := []struct {
+		name    string
+		opts    []ledger.Option
+		expPass bool
+	}{
+		{
+			"pass - no prefix configured",
+			[]ledger.Option{ledger.WithRegisteredHRP(big.NewInt(9000), "cosmos")},
+			true,
+		},
+		{
+			"pass - configured prefix of the domain chain ID",
+			[]ledger.Option{ledger.WithRegisteredHRP(big.NewInt(9000), "cosmos"), ledger.WithHRP("cosmos")},
+			true,
+		},
+		{
+			"fail - configured prefix mismatching the domain chain ID",
+			[]ledger.Option{ledger.WithRegisteredHRP(big.NewInt(9000), "cosmos"), ledger.WithHRP("evmos")},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			for _, opt := range tc.opts {
+				opt(suite.ledger)
+			}
+			RegisterOpen(suite.mockWallet)
+			RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+
+			_, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+			if !tc.expPass {
+				suite.Require().ErrorIs(err, ledger.ErrInvalidHRP)
+				suite.mockWallet.AssertNotCalled(suite.T(), "SignTypedData", account, mock.Anything)
+				return
+			}
+
+			suite.Require().NoError(err)
+		})
+	}
+}
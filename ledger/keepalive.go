@@ -0,0 +1,84 @@
+package ledger
+
+import (
+	"context"
+	"time"
+
+	sdkledger "github.com/cosmos/cosmos-sdk/crypto/ledger"
+)
+
+const (
+	// keepConnectedPollInterval is the interval at which KeepConnected checks that
+	// the device is still attached.
+	keepConnectedPollInterval = time.Second
+
+	// keepConnectedMinBackoff and keepConnectedMaxBackoff bound the delay between
+	// two connection attempts of KeepConnected, which doubles after each failure.
+	keepConnectedMinBackoff = 250 * time.Millisecond
+	keepConnectedMaxBackoff = 30 * time.Second
+)
+
+// KeepConnected maintains a live connection to the Ledger until ctx is done, which
+// spares long-running services from re-implementing the reconnection logic. The
+// device is checked every second, and once it drops (e.g. it was unplugged or the
+// Ethereum app was closed), onDisconnect is called and it is reconnected with an
+// exponential backoff, from 250ms up to 30s between attempts. onConnect is called
+// with the connected device after every successful connection, including the
+// initial one if the device is already connected. Both callbacks are optional, run
+// on the calling goroutine and must not block. ctx.Err() is returned once ctx is
+// done, without calling onDisconnect.
+func (e *EvmosSECP256K1) KeepConnected(ctx context.Context, onConnect func(sdkledger.SECP256K1), onDisconnect func()) error {
+	connected := e.DeviceConnected()
+	if connected && onConnect != nil {
+		onConnect(e)
+	}
+
+	backoff := keepConnectedMinBackoff
+	for {
+		delay := keepConnectedPollInterval
+		if !connected {
+			if err := e.reconnectWithContext(ctx); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				e.log().Debugf("Unable to connect to Ledger, retrying in %s: %v", backoff, err)
+
+				delay = backoff
+				backoff *= 2
+				if backoff > keepConnectedMaxBackoff {
+					backoff = keepConnectedMaxBackoff
+				}
+			} else {
+				connected, backoff = true, keepConnectedMinBackoff
+				if onConnect != nil {
+					onConnect(e)
+				}
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if connected && !e.DeviceConnected() {
+			connected = false
+			e.log().Debugf("Ledger disconnected, reconnecting")
+			if onDisconnect != nil {
+				onDisconnect()
+			}
+		}
+	}
+}
+
+// reconnectWithContext connects to the Ledger again, or for the first time, unless
+// ctx is done first.
+func (e *EvmosSECP256K1) reconnectWithContext(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.reconnect(ctx)
+}
@@ -0,0 +1,50 @@
+package ledger_test
+
+import (
+	"context"
+	"time"
+
+	sdkledger "github.com/cosmos/cosmos-sdk/crypto/ledger"
+
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestKeepConnected() {
+	testCases := []struct {
+		name    string
+		timeout time.Duration
+	}{
+		{
+			"stopped - context already done",
+			0,
+		},
+		{
+			"stopped - device never connected",
+			600 * time.Millisecond,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			suite.ledger.PrimaryWallet = nil
+			transport := &bridgeTransport{}
+			ledger.WithTransport(transport)(suite.ledger)
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			var connects, disconnects int
+			err := suite.ledger.KeepConnected(ctx,
+				func(sdkledger.SECP256K1) { connects++ },
+				func() { disconnects++ },
+			)
+			suite.Require().ErrorIs(err, context.DeadlineExceeded)
+			suite.Require().Zero(connects)
+			suite.Require().Zero(disconnects)
+
+			// No device was detected, so the primary wallet is unset
+			suite.Require().Nil(suite.ledger.PrimaryWallet)
+		})
+	}
+}
@@ -1,15 +1,22 @@
 package ledger
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"math/big"
 	"strings"
+	"sync"
+	"time"
 
 	sdkledger "github.com/cosmos/cosmos-sdk/crypto/ledger"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/evmos/evmos-ledger-go/accounts"
 	"github.com/evmos/evmos-ledger-go/usbwallet"
@@ -19,46 +26,437 @@ import (
 // Secp256k1DerivationFn defines the derivation function used on the Cosmos SDK Keyring.
 type Secp256k1DerivationFn func() (sdkledger.SECP256K1, error)
 
+// EvmosLedgerDerivation returns a derivation function that connects to the first
+// hardware wallet detected.
 func EvmosLedgerDerivation() Secp256k1DerivationFn {
-	evmosSECP256K1 := new(EvmosSECP256K1)
+	return EvmosLedgerDerivationWithOptions()
+}
+
+// EvmosLedgerDerivationForDevice returns a derivation function that connects to the
+// hardware wallet found at the given index, as listed by ListWallets. This allows
+// selecting a device when multiple Ledgers are connected.
+func EvmosLedgerDerivationForDevice(index int, opts ...Option) Secp256k1DerivationFn {
+	return EvmosLedgerDerivationWithOptions(append([]Option{WithWalletIndex(index)}, opts...)...)
+}
+
+// EvmosLedgerDerivationWithOptions returns a derivation function that connects to a
+// hardware wallet, using an EvmosSECP256K1 configured with the given options.
+func EvmosLedgerDerivationWithOptions(opts ...Option) Secp256k1DerivationFn {
+	return NewEvmosSECP256K1(opts...).Connect
+}
+
+// EvmosLedgerDerivationWithContext behaves like EvmosLedgerDerivationWithOptions, but
+// the returned derivation function stops detecting and opening the hardware wallet
+// once the provided context is done, in which case ctx.Err() is returned. This
+// allows cancelling the connection to a device which enumerates but never responds.
+func EvmosLedgerDerivationWithContext(ctx context.Context, opts ...Option) Secp256k1DerivationFn {
+	evmosSECP256K1 := NewEvmosSECP256K1(opts...)
 
 	return func() (sdkledger.SECP256K1, error) {
-		return evmosSECP256K1.connectToLedgerApp()
+		return evmosSECP256K1.ConnectWithContext(ctx)
 	}
 }
 
+// NewEvmosSECP256K1 returns an EvmosSECP256K1 configured with the given options,
+// without connecting to any device. Call Connect to connect to the hardware wallet.
+func NewEvmosSECP256K1(opts ...Option) *EvmosSECP256K1 {
+	evmosSECP256K1 := &EvmosSECP256K1{}
+	for _, opt := range opts {
+		opt(evmosSECP256K1)
+	}
+
+	return evmosSECP256K1
+}
+
 var _ sdkledger.SECP256K1 = &EvmosSECP256K1{}
 
 // EvmosSECP256K1 defines a wrapper of the Ethereum App to
 // for compatibility with Cosmos SDK chains.
+//
+// All operations involving the primary wallet are serialized through an internal
+// mutex, since the device can only process one request at a time. An instance can
+// therefore be shared by multiple goroutines, as long as the exported fields are
+// not modified concurrently.
 type EvmosSECP256K1 struct {
 	*usbwallet.Hub
 	PrimaryWallet accounts.Wallet
+
+	mu    sync.Mutex   // Serializes the operations on the primary wallet
+	hrpMu sync.RWMutex // Guards allowedHRPs, chainHRPs and hrpChainID, which are read without holding mu
+
+	abortMu     sync.Mutex                         // Guards aborts and nextAbortID, which are modified without holding mu
+	aborts      map[uint64]context.CancelCauseFunc // Cancels the in-flight signing requests (see AbortCurrentOperation)
+	nextAbortID uint64
+
+	logger             Logger
+	promptWriter       io.Writer
+	promptCallback     func(Stage)
+	walletIndex        int
+	deviceFilter       func(WalletInfo) bool           // Selects the primary wallet instead of walletIndex if set
+	deviceSelector     func([]WalletInfo) (int, error) // Chooses the primary wallet among multiple ones if set
+	connectTimeout     time.Duration
+	refreshInterval    time.Duration           // Time between the device scans of the hub, default if zero
+	commandTimeout     time.Duration           // Timeout of each command sent to the device, unbounded if zero
+	closeTimeout       time.Duration           // Timeout of closing the wallet, unbounded if zero
+	apduRecorder       *usbwallet.APDURecorder // Recorder of the APDUs exchanged with the device, if any
+	transport          usbwallet.Transport     // Transport used to reach the device, USB HID if unset
+	passphraseFn       func() (string, error)
+	retryAttempts      int
+	retryDelay         time.Duration
+	cacheEnabled       bool
+	cache              map[string]accounts.Account
+	inflight           singleflight.Group // Coalesces the concurrent derivations of the same HD path
+	allowedHRPs        map[string]struct{}
+	chainHRPs          map[string]string // HRPs registered by chain ID (see RegisterHRP)
+	hrpChainID         string            // Expected chain ID whose registered HRP is enforced, if any
+	hrp                string
+	displayHashes      bool
+	fullDisplayVersion *[3]byte // Minimum app version with EIP-712 full display, default if nil
+	displayMode        DisplayMode
+	forceConfirmation  bool // Displays the signing address for confirmation before each signature
+	verifySignatures   bool // Re-derives the signing account after each signature to check the signer
+	metrics            Metrics
+	coinType           *uint32             // Expected coin type of HD paths, Ethereum if unset
+	allowedCoinTypes   map[uint32]struct{} // Coin types accepted in HD paths besides coinType
+	pathTemplate       []uint32
+	pathIndex          int // Position of the index in pathTemplate
+	typedDataFn        TypedDataBuilder
+	tokens             []accounts.TokenInfo // ERC-20 token descriptors provided before signing
+	expectedChainID    *big.Int             // Chain ID required in EIP-712 domains, unchecked if nil
+	expectedDomain     *expectedDomain      // Expected EIP-712 domain fields, unchecked if nil
+	closed             bool                 // Set by Close until the next connection
+	noAutoReopen       bool                 // Requests fail instead of reopening a closed wallet
+	autoOpenApp        bool                 // Launches the Ethereum app when connecting to a device on the dashboard
+}
+
+// SetLogger sets the logger used to report progress and diagnostic messages.
+// Passing nil restores the default logger, which prints to stdout.
+func (e *EvmosSECP256K1) SetLogger(logger Logger) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.logger = logger
+}
+
+// log returns the configured logger, falling back to the stdout logger.
+func (e *EvmosSECP256K1) log() Logger {
+	if e.logger == nil {
+		return StdoutLogger{}
+	}
+	return e.logger
 }
 
-// Close closes the associated primary wallet. Any requests on
-// the object after a successful Close() should not work
-func (e EvmosSECP256K1) Close() error {
+// SetPromptWriter directs the interactive prompts meant for the user (e.g. the
+// reminder to check the Ledger or the EIP-712 hashes to compare with the device)
+// to the given writer, one prompt per line, separately from the diagnostic messages
+// of the logger. Passing nil restores the default, which reports the prompts as
+// informational messages of the logger, i.e. on stdout unless a logger is set.
+func (e *EvmosSECP256K1) SetPromptWriter(w io.Writer) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.promptWriter = w
+}
+
+// prompt shows an interactive prompt to the user.
+func (e *EvmosSECP256K1) prompt(format string, args ...interface{}) {
+	if e.promptWriter == nil {
+		e.log().Infof(format, args...)
+		return
+	}
+
+	//#nosec G703 -- prompts are best effort, signing must not fail because of them
+	_, _ = fmt.Fprintf(e.promptWriter, format+"\n", args...)
+}
+
+// Close closes the associated primary wallet, releases the reference to it and
+// overwrites the cached public keys. The object must not be reused after Close,
+// except through the derivation function that created it, which connects to the
+// device again. Close is idempotent: closing an object that was already closed,
+// or whose wallet was already closed, returns nil, so that it can be deferred in
+// multiple places. If a close timeout is set (see SetCloseTimeout) and the device
+// doesn't release the connection in time, ErrCloseTimeout is returned, but the
+// references are released all the same.
+func (e *EvmosSECP256K1) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return nil
+	}
+
 	if e.PrimaryWallet == nil {
 		return errors.New("could not close Ledger: no wallet found")
 	}
 
-	return e.PrimaryWallet.Close()
+	err := e.closeWallet(e.PrimaryWallet)
+	if errors.Is(err, gethaccounts.ErrWalletClosed) {
+		err = nil
+	}
+
+	e.PrimaryWallet = nil
+	e.closed = true
+	e.wipeCache()
+
+	return err
 }
 
-// GetPublicKeySECP256K1 returns the public key associated with the address derived from
-// the provided hdPath using the primary wallet
-func (e EvmosSECP256K1) GetPublicKeySECP256K1(hdPath []uint32) ([]byte, error) {
+// closeWallet closes the wallet, giving up once the close timeout expires. The
+// wallet is still being closed in the background after a timeout, and its
+// connection is released once the device lets go of it.
+//
+// Note, closeWallet assumes the lock is held!
+func (e *EvmosSECP256K1) closeWallet(wallet accounts.Wallet) error {
+	if e.closeTimeout <= 0 {
+		return wallet.Close()
+	}
+
+	// Buffered so the goroutine can exit once the wallet is closed, even after the
+	// timeout expired
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- wallet.Close()
+	}()
+
+	timer := time.NewTimer(e.closeTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("%w after %s", ErrCloseTimeout, e.closeTimeout)
+	}
+}
+
+// Open opens the primary wallet, for embedders that manage the device lifecycle
+// themselves. Opening a wallet that is already open is not an error. Note that the
+// other methods still open the wallet if needed, since the Cosmos SDK keyring closes
+// the device after every request, unless automatic reopening is disabled (see
+// SetAutoReopen).
+func (e *EvmosSECP256K1) Open() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return errors.New("could not open Ledger: no wallet found")
+	}
+
+	return e.reopen()
+}
+
+// SetAutoReopen enables or disables reopening the primary wallet at the start of
+// every request. It is enabled by default, since the Cosmos SDK keyring closes the
+// device after every request. Embedders managing the device lifecycle themselves
+// can disable it to avoid the redundant HID traffic: the requests then assume that
+// the wallet was opened with Open, and fail with ErrWalletClosed if it wasn't.
+func (e *EvmosSECP256K1) SetAutoReopen(enabled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.noAutoReopen = !enabled
+}
+
+// SetAutoOpenApp enables or disables launching the Ethereum app when connecting to a
+// device on the dashboard, e.g. right after it was plugged in and unlocked, instead
+// of failing with ErrAppNotOpen. The user must still confirm opening the app on the
+// device, and ErrAppNotOpen is returned if the app could not be launched, e.g.
+// because the user declined or the app is not installed. It is disabled by default.
+func (e *EvmosSECP256K1) SetAutoOpenApp(enabled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.autoOpenApp = enabled
+}
+
+// IsOpen reports whether the primary wallet is currently open.
+func (e *EvmosSECP256K1) IsOpen() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return false
+	}
+
+	status, _ := e.PrimaryWallet.Status()
+	return status != usbwallet.StatusClosed
+}
+
+// Wallet returns the primary wallet, or nil if no device is connected, so that
+// advanced callers can use the wallet methods which are not wrapped by
+// EvmosSECP256K1. The calls made on the returned wallet bypass the internal mutex:
+// callers must not use it concurrently with the methods of EvmosSECP256K1, and
+// the wallet may have to be opened first, as it is closed by the Cosmos SDK
+// keyring after every request.
+func (e *EvmosSECP256K1) Wallet() accounts.Wallet {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.PrimaryWallet
+}
+
+// SetCommandTimeout bounds the time spent on each APDU sent to the device, such as
+// the ones of a derivation or a signing request, independently of the connect
+// timeout. Once a command times out, ErrCommandTimeout is returned and the
+// connection to the device is abandoned, so the wallet must be closed and opened
+// again before further requests, e.g. with Reconnect. Since signing requests
+// wait for the user to confirm them on the device, the timeout must leave enough
+// time for it. The timeout applies from the next time the wallet is opened. A zero
+// duration, the default, disables it.
+func (e *EvmosSECP256K1) SetCommandTimeout(timeout time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.commandTimeout = timeout
+	if e.Hub != nil {
+		e.Hub.SetCommandTimeout(timeout)
+	}
+}
+
+// SetCloseTimeout bounds the time Close waits for the device to release the
+// connection, so that the graceful shutdown of a daemon doesn't stall on a wedged
+// device. Once it expires, Close returns ErrCloseTimeout and releases the wallet
+// regardless, while the connection is closed in the background. A zero duration,
+// the default, disables it.
+func (e *EvmosSECP256K1) SetCloseTimeout(timeout time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.closeTimeout = timeout
+}
+
+// SetAPDURecorder writes a transcript of the APDU command/response pairs exchanged
+// with the device to the given writer, to help diagnose device issues and file bug
+// reports against the Ethereum app. If redact is set, the payloads of the exchanges,
+// such as the derivation paths, transactions and public keys, are left out and only
+// their lengths, the command headers and the status words are written. Recording
+// starts from the next time the wallet is opened. A nil writer, the default,
+// disables it.
+func (e *EvmosSECP256K1) SetAPDURecorder(w io.Writer, redact bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.apduRecorder = nil
+	if w != nil {
+		e.apduRecorder = usbwallet.NewAPDURecorder(w, redact)
+	}
+	if e.Hub != nil {
+		e.Hub.SetAPDURecorder(e.apduRecorder)
+	}
+}
+
+// open opens the primary wallet if it was closed, or only checks that it is open
+// if automatic reopening is disabled.
+//
+// Note, open assumes the lock is held!
+func (e *EvmosSECP256K1) open() error {
+	if !e.noAutoReopen {
+		return e.reopen()
+	}
+
+	if status, _ := e.PrimaryWallet.Status(); status == usbwallet.StatusClosed {
+		return fmt.Errorf("could not use Ledger: %w, and automatic reopening is disabled", ErrWalletClosed)
+	}
+
+	return nil
+}
+
+// reopen opens the primary wallet if it was closed. Unlike the wallet Open method,
+// which fails if the wallet is already open, reopen only returns the errors that
+// prevent using the wallet.
+//
+// Note, reopen assumes the lock is held!
+func (e *EvmosSECP256K1) reopen() error {
+	err := e.openWallet(context.Background(), e.PrimaryWallet)
+	if err != nil && !errors.Is(err, gethaccounts.ErrWalletAlreadyOpen) {
+		return fmt.Errorf("could not open Ledger: %w", err)
+	}
+
+	return nil
+}
+
+// DeviceConnected reports whether the primary wallet is still attached to the machine
+// and has not encountered a device failure. No request that requires user interaction
+// is sent to the device.
+func (e *EvmosSECP256K1) DeviceConnected() bool {
+	_, err := e.DeviceStatus()
+	return err == nil
+}
+
+// DeviceStatus returns the textual status of the primary wallet (e.g. "Ethereum app
+// v1.10.2 online") after verifying that the device is still detected by the hub.
+func (e *EvmosSECP256K1) DeviceStatus() (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	if e.PrimaryWallet == nil {
-		return nil, errors.New("could not get Ledger public key: no wallet found")
+		return "", errors.New("could not get Ledger status: no wallet found")
+	}
+
+	if e.Hub == nil {
+		return "", errors.New("could not get Ledger status: no hardware wallet hub found")
 	}
 
-	// Re-open wallet in case it was closed. Do not handle the error here (see SignSECP256K1)
-	_ = e.PrimaryWallet.Open("")
+	url := e.PrimaryWallet.URL()
 
-	account, err := e.PrimaryWallet.Derive(hdPath, true)
+	connected := false
+	for _, wallet := range e.Wallets() {
+		if wallet.URL().Cmp(url) == 0 {
+			connected = true
+			break
+		}
+	}
+
+	if !connected {
+		return "", errors.New("could not get Ledger status: device disconnected")
+	}
+
+	return e.PrimaryWallet.Status()
+}
+
+// Reconnect recovers from a device that was unplugged and plugged back in, which
+// otherwise leaves the primary wallet permanently unusable. The current wallet is
+// closed and the USB devices are scanned again. The same device is selected again
+// if it can still be found, otherwise the wallet at the configured index is used.
+// The primary wallet is unset if no device could be opened.
+func (e *EvmosSECP256K1) Reconnect() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.reconnect(context.Background())
+}
+
+// reconnect closes the primary wallet, if any, and connects to the same device
+// again, or to the wallet at the configured index if it can't be found.
+//
+// Note, reconnect assumes the lock is held!
+func (e *EvmosSECP256K1) reconnect(ctx context.Context) error {
+	var previousURL string
+	if e.PrimaryWallet != nil {
+		previousURL = e.PrimaryWallet.URL().String()
+		//#nosec G703 -- the wallet is replaced, so a failure to close it is not relevant
+		_ = e.PrimaryWallet.Close()
+	}
+
+	// Nothing is left to release until connected again, so Close doesn't fail if the
+	// connection does
+	e.PrimaryWallet = nil
+	e.closed = true
+	e.clearCache()
+
+	if err := e.connect(ctx, previousURL); err != nil {
+		return fmt.Errorf("could not reconnect to Ledger: %w", err)
+	}
+
+	return nil
+}
+
+// GetPublicKeySECP256K1 returns the public key associated with the address derived from
+// the provided hdPath using the primary wallet
+func (e *EvmosSECP256K1) GetPublicKeySECP256K1(hdPath []uint32) ([]byte, error) {
+	account, err := e.sharedDerive(hdPath)
 	if err != nil {
-		return nil, errors.New("unable to derive public key, please retry")
+		return nil, err
 	}
 
 	pubkeyBz := crypto.FromECDSAPub(account.PublicKey)
@@ -66,22 +464,113 @@ func (e EvmosSECP256K1) GetPublicKeySECP256K1(hdPath []uint32) ([]byte, error) {
 	return pubkeyBz, nil
 }
 
+// sharedDerive derives the account at the given HD path without displaying it.
+// Concurrent calls for the same path share a single device round-trip, instead of
+// queueing one request per caller, which matters for services serving many clients
+// reading the same account.
+func (e *EvmosSECP256K1) sharedDerive(hdPath []uint32) (accounts.Account, error) {
+	// Equivalent paths, e.g. with or without the hardened markers, share the request
+	key := cacheKey(hdPath)
+
+	result, err, _ := e.inflight.Do(key, func() (interface{}, error) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		if e.PrimaryWallet == nil {
+			return nil, errors.New("could not derive Ledger account: no wallet found")
+		}
+
+		// Re-open wallet in case it was closed
+		if err := e.open(); err != nil {
+			return nil, err
+		}
+
+		account, err := e.cachedDerive(context.Background(), hdPath, false)
+		if err != nil {
+			return nil, fmt.Errorf("unable to derive Ledger address, please open the Ethereum app and retry: %w", err)
+		}
+
+		return account, nil
+	})
+	if err != nil {
+		return accounts.Account{}, err
+	}
+
+	return result.(accounts.Account), nil
+}
+
+// GetCompressedPublicKeySECP256K1 behaves like GetPublicKeySECP256K1, but returns the
+// 33-byte compressed public key used by the Cosmos SDK secp256k1 keys, instead of
+// the 65-byte uncompressed one.
+func (e *EvmosSECP256K1) GetCompressedPublicKeySECP256K1(hdPath []uint32) ([]byte, error) {
+	pubkeyBz, err := e.GetPublicKeySECP256K1(hdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pubkey, err := crypto.UnmarshalPubkey(pubkeyBz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key returned by Ledger: %w", err)
+	}
+
+	return crypto.CompressPubkey(pubkey), nil
+}
+
 // GetAddressPubKeySECP256K1 takes in the HD path as well as a "Human Readable Prefix" (HRP, e.g. "evmos")
 // to return the public key bytes in secp256k1 format as well as the account address.
-func (e EvmosSECP256K1) GetAddressPubKeySECP256K1(hdPath []uint32, hrp string) ([]byte, string, error) {
+// ErrInvalidHRP is returned if the HRP is malformed or not allowed (see SetAllowedHRPs).
+func (e *EvmosSECP256K1) GetAddressPubKeySECP256K1(hdPath []uint32, hrp string) ([]byte, string, error) {
+	if err := e.validateHRP(hrp); err != nil {
+		return nil, "", err
+	}
+
+	account, err := e.sharedDerive(hdPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	address, err := FormatBech32(account.Address.Bytes(), hrp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return crypto.FromECDSAPub(account.PublicKey), address, nil
+}
+
+// GetAddressPubKeySECP256K1WithConfig behaves like GetAddressPubKeySECP256K1, but
+// encodes the address with the account prefix of the given Cosmos SDK config instead
+// of an HRP. Only the given config is consulted, so the result doesn't depend on the
+// global one returned by sdk.GetConfig.
+func (e *EvmosSECP256K1) GetAddressPubKeySECP256K1WithConfig(hdPath []uint32, config *sdk.Config) ([]byte, string, error) {
+	if config == nil {
+		return nil, "", errors.New("could not get Ledger address: no bech32 config provided")
+	}
+
+	return e.GetAddressPubKeySECP256K1(hdPath, config.GetBech32AccountAddrPrefix())
+}
+
+// GetAddressPubKeySECP256K1WithDisplay behaves like GetAddressPubKeySECP256K1. If display
+// is set, the Ledger additionally shows the derived address and waits for the user to
+// confirm it, which is recommended when setting up an account. ErrUserRejected is
+// returned if the user declines the address.
+func (e *EvmosSECP256K1) GetAddressPubKeySECP256K1WithDisplay(hdPath []uint32, hrp string, display bool) ([]byte, string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	if e.PrimaryWallet == nil {
 		return nil, "", errors.New("could not get Ledger address: no wallet found")
 	}
 
-	// Re-open wallet in case it was closed. Ignore the error here (see SignSECP256K1)
-	_ = e.PrimaryWallet.Open("")
+	if err := e.validateHRP(hrp); err != nil {
+		return nil, "", err
+	}
 
-	account, err := e.PrimaryWallet.Derive(hdPath, true)
-	if err != nil {
-		return nil, "", errors.New("unable to derive Ledger address, please open the Ethereum app and retry")
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return nil, "", err
 	}
 
-	address, err := sdk.Bech32ifyAddressBytes(hrp, account.Address.Bytes())
+	account, address, err := e.getAccount(hdPath, hrp, display)
 	if err != nil {
 		return nil, "", err
 	}
@@ -91,93 +580,552 @@ func (e EvmosSECP256K1) GetAddressPubKeySECP256K1(hdPath []uint32, hrp string) (
 	return pubkeyBz, address, nil
 }
 
+// getAccount derives the account at the given HD path and encodes its address
+// with the given "Human Readable Prefix".
+//
+// Note, getAccount assumes the lock is held!
+func (e *EvmosSECP256K1) getAccount(hdPath []uint32, hrp string, display bool) (accounts.Account, string, error) {
+	if display {
+		e.prompt("Please verify the address displayed on your Ledger...")
+	}
+
+	account, err := e.cachedDerive(context.Background(), hdPath, display)
+	if err != nil {
+		return accounts.Account{}, "", fmt.Errorf("unable to derive Ledger address, please open the Ethereum app and retry: %w", err)
+	}
+
+	address, err := FormatBech32(account.Address.Bytes(), hrp)
+	if err != nil {
+		return accounts.Account{}, "", err
+	}
+
+	return account, address, nil
+}
+
 // SignSECP256K1 returns the signature bytes generated from signing a transaction
-// using the EIP712 signature.
-func (e EvmosSECP256K1) SignSECP256K1(hdPath []uint32, signDocBytes []byte) ([]byte, error) {
-	fmt.Printf("Generating payload, please check your Ledger...\n")
+// using the EIP712 signature. The signature is in the 65-byte Ethereum [R || S || V]
+// format, where V is always normalized to 27 or 28. Use SignSECP256K1Cosmos to get
+// the 64-byte [R || S] format instead, or ParseSignature to access its components.
+func (e *EvmosSECP256K1) SignSECP256K1(hdPath []uint32, signDocBytes []byte) ([]byte, error) {
+	return e.SignSECP256K1WithContext(context.Background(), hdPath, signDocBytes)
+}
+
+// SignSECP256K1WithContext behaves like SignSECP256K1, but stops waiting for the
+// device once the provided context is done. In that case, ctx.Err() is returned
+// and any signature produced afterwards by the device is discarded.
+func (e *EvmosSECP256K1) SignSECP256K1WithContext(ctx context.Context, hdPath []uint32, signDocBytes []byte) ([]byte, error) {
+	e.promptSigning()
+	e.reportStage(StageBuild)
+
+	typedData, err := e.buildTypedData(signDocBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.SignTypedDataWithContext(ctx, hdPath, typedData)
+}
+
+// TypedDataBuilder converts the sign doc bytes passed to SignSECP256K1 into the
+// EIP-712 typed data signed by the Ledger.
+type TypedDataBuilder func(signDocBytes []byte) (apitypes.TypedData, error)
+
+// SetTypedDataBuilder overrides the conversion of sign docs into EIP-712 typed
+// data, for chains whose typed data layout differs from the one of Evmos. Passing
+// nil restores the default builder, eip712.GetEIP712TypedDataForMsg.
+func (e *EvmosSECP256K1) SetTypedDataBuilder(builder TypedDataBuilder) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.typedDataFn = builder
+}
+
+// buildTypedData validates the sign doc bytes and converts them into EIP-712 typed
+// data, using the configured builder. The builder runs without holding the lock,
+// and a panic while parsing the sign doc is returned as ErrInvalidSignDoc.
+func (e *EvmosSECP256K1) buildTypedData(signDocBytes []byte) (typedData apitypes.TypedData, err error) {
+	if err := validateSignDoc(signDocBytes); err != nil {
+		return apitypes.TypedData{}, err
+	}
+
+	e.mu.Lock()
+	builder := e.typedDataFn
+	e.mu.Unlock()
+
+	if builder == nil {
+		builder = eip712.GetEIP712TypedDataForMsg
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			typedData, err = apitypes.TypedData{}, fmt.Errorf("%w: %v", ErrInvalidSignDoc, r)
+		}
+	}()
+
+	return builder(signDocBytes)
+}
 
+// SignTypedData signs an already constructed EIP-712 typed data object with the
+// account derived from the provided hdPath, bypassing the Cosmos sign doc conversion
+// of SignSECP256K1. The signature is returned in the same format as SignSECP256K1.
+func (e *EvmosSECP256K1) SignTypedData(hdPath []uint32, typedData apitypes.TypedData) ([]byte, error) {
+	return e.SignTypedDataWithContext(context.Background(), hdPath, typedData)
+}
+
+// SignTypedDataWithContext behaves like SignTypedData, but stops waiting for the
+// device once the provided context is done (see SignSECP256K1WithContext).
+func (e *EvmosSECP256K1) SignTypedDataWithContext(ctx context.Context, hdPath []uint32, typedData apitypes.TypedData) ([]byte, error) {
+	return e.signWithContext(ctx, func(ctx context.Context) ([]byte, error) {
+		result, err := e.signTypedData(ctx, hdPath, typedData)
+		return result.Signature, err
+	})
+}
+
+// SignSECP256K1WithAccount behaves like SignSECP256K1, but signs with an account
+// previously obtained from DeriveAccount instead of deriving it again, which saves
+// a round-trip to the device when the address was just derived (e.g. to display
+// it). The account is only known by the wallet until it is closed, after which it
+// must be derived again.
+func (e *EvmosSECP256K1) SignSECP256K1WithAccount(account accounts.Account, signDocBytes []byte) ([]byte, error) {
+	e.promptSigning()
+	e.reportStage(StageBuild)
+
+	typedData, err := e.buildTypedData(signDocBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+
+	return e.signWithContext(ctx, func(ctx context.Context) ([]byte, error) {
+		if err := e.prepareSign(ctx); err != nil {
+			return nil, err
+		}
+
+		signature, _, err := e.signTypedDataWithAccount(ctx, nil, account, typedData)
+		return signature, err
+	})
+}
+
+// signWithContext runs sign while holding the lock, and stops waiting for it once
+// the provided context is done or the request is aborted (see
+// AbortCurrentOperation). The context passed to sign is cancelled in both cases.
+func (e *EvmosSECP256K1) signWithContext(ctx context.Context, sign func(context.Context) ([]byte, error)) ([]byte, error) {
+	ctx, release := e.abortable(ctx)
+	defer release()
+
+	type signResult struct {
+		signature []byte
+		err       error
+	}
+
+	// Buffered so the goroutine can always deliver its result and exit, even if
+	// nobody is listening anymore
+	resultCh := make(chan signResult, 1)
+
+	// Perform the device I/O on a separate goroutine, so that the caller can stop
+	// waiting for the user confirmation once ctx is done. The lock is held until the
+	// device replies, so that no other request is sent while a prompt is pending.
+	go func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		signature, err := sign(ctx)
+		resultCh <- signResult{signature: signature, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	case res := <-resultCh:
+		if res.err != nil && ctx.Err() != nil {
+			return nil, context.Cause(ctx)
+		}
+		return res.signature, res.err
+	}
+}
+
+// prepareSign checks that the primary wallet can be used for signing, and opens it.
+//
+// Note, prepareSign assumes the lock is held!
+func (e *EvmosSECP256K1) prepareSign(ctx context.Context) error {
 	if e.PrimaryWallet == nil {
-		return nil, errors.New("unable to sign with Ledger: no wallet found")
+		return errors.New("unable to sign with Ledger: no wallet found")
 	}
 
-	// Re-open wallet in case it was closed. Since an error occurs if the wallet is already open,
-	// ignore the error. Any errors due to the wallet being closed will surface later on.
-	_ = e.PrimaryWallet.Open("")
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Re-open wallet in case it was closed
+	return e.open()
+}
+
+// signTypedData derives the account and signs the typed data using EIP-712.
+//
+// Note, signTypedData assumes the lock is held!
+func (e *EvmosSECP256K1) signTypedData(ctx context.Context, hdPath []uint32, typedData apitypes.TypedData) (SignResult, error) {
+	if err := e.prepareSign(ctx); err != nil {
+		return SignResult{}, err
+	}
 
 	// Derive requested account
-	account, err := e.PrimaryWallet.Derive(hdPath, true)
+	account, err := e.deriveSigner(ctx, hdPath)
 	if err != nil {
-		return nil, errors.New("unable to derive Ledger address, please open the Ethereum app and retry")
+		return SignResult{}, err
 	}
 
-	typedData, err := eip712.GetEIP712TypedDataForMsg(signDocBytes)
+	signature, displayedAsHash, err := e.signTypedDataWithAccount(ctx, hdPath, account, typedData)
 	if err != nil {
-		return nil, err
+		return SignResult{}, err
+	}
+
+	if err := e.verifyTypedDataSignature(ctx, hdPath, typedData, signature); err != nil {
+		return SignResult{}, err
+	}
+
+	return SignResult{
+		Signature:       signature,
+		Address:         account.Address,
+		HDPath:          append([]uint32(nil), hdPath...),
+		DisplayedAsHash: displayedAsHash,
+	}, nil
+}
+
+// signTypedDataWithAccount signs the typed data using EIP-712 with the given account,
+// and reports whether the device displayed the hashes of the message instead of its
+// fields. Signing errors mention the HD path of the account, unless it is nil.
+//
+// Note, signTypedDataWithAccount assumes the lock is held!
+func (e *EvmosSECP256K1) signTypedDataWithAccount(ctx context.Context, hdPath []uint32, account accounts.Account, typedData apitypes.TypedData) ([]byte, bool, error) {
+	if err := e.verifyChainID(typedData); err != nil {
+		return nil, false, err
+	}
+
+	if err := e.verifyDomain(typedData); err != nil {
+		return nil, false, err
+	}
+
+	if err := e.verifyHRP(typedData); err != nil {
+		return nil, false, err
 	}
 
 	// Display EIP-712 message hash for user to verify
 	if err := e.displayEIP712Hash(typedData); err != nil {
-		return nil, fmt.Errorf("unable to generate EIP-712 hash for object: %w", err)
+		return nil, false, fmt.Errorf("unable to generate EIP-712 hash for object: %w", err)
 	}
 
+	e.reportStage(StageAwaitConfirmation)
+
 	// Sign with EIP712 signature
-	signature, err := e.PrimaryWallet.SignTypedData(account, typedData)
+	var (
+		signature       []byte
+		displayedAsHash bool
+	)
+	err := e.observeSign(func() error {
+		return e.retry(ctx, func() (err error) {
+			signature, displayedAsHash, err = e.signTypedDataWithTokens(account, typedData)
+			return err
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error generating signature, please retry: %w", err)
+		err = e.blindSigningError(err)
+		if hdPath != nil {
+			err = pathError("sign", hdPath, err)
+		}
+		return nil, false, fmt.Errorf("error generating signature, please retry: %w", err)
 	}
 
-	return signature, nil
+	// Depending on the app version, V is either returned as 27/28 or as 0/1
+	signature, err = NormalizeSignature(signature)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return signature, displayedAsHash, nil
 }
 
-// displayEIP712Hash is a helper function to display the EIP-712 hashes.
-// This allows users to verify the hashed message they are signing via Ledger.
-func (e EvmosSECP256K1) displayEIP712Hash(typedData apitypes.TypedData) error {
-	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+// SetDisplayEIP712Hashes enables or disables logging the EIP-712 domain and message
+// hashes before signing, so that users can compare them with the ones shown on the
+// Ledger. The hashes are not logged by default. Use ComputeEIP712Hashes to present
+// them differently, e.g. in a confirmation dialog.
+func (e *EvmosSECP256K1) SetDisplayEIP712Hashes(display bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.displayHashes = display
+}
+
+// ComputeEIP712Hashes returns the domain separator and the message hash of the typed
+// data, which are the values displayed by the Ledger when signing it.
+func ComputeEIP712Hashes(typedData apitypes.TypedData) (domainHash, messageHash []byte, err error) {
+	domainHash, err = typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
 	if err != nil {
-		return err
+		return nil, nil, err
+	}
+	messageHash, err = typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, nil, err
 	}
-	typedDataHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+
+	return domainHash, messageHash, nil
+}
+
+// displayEIP712Hash is a helper function to display the EIP-712 hashes, if enabled.
+// This allows users to verify the hashed message they are signing via Ledger.
+//
+// Note, displayEIP712Hash assumes the lock is held!
+func (e *EvmosSECP256K1) displayEIP712Hash(typedData apitypes.TypedData) error {
+	domainSeparator, typedDataHash, err := ComputeEIP712Hashes(typedData)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Signing the following payload with EIP-712:\n")
-	fmt.Printf("- Domain: %s\n", bytesToHexString(domainSeparator))
-	fmt.Printf("- Message: %s\n", bytesToHexString(typedDataHash))
+	if !e.displayHashes {
+		return nil
+	}
+
+	e.prompt("Signing the following payload with EIP-712:")
+	e.prompt("- Domain: %s", bytesToHexString(domainSeparator))
+	e.prompt("- Message: %s", bytesToHexString(typedDataHash))
 
 	return nil
 }
 
-func (e *EvmosSECP256K1) connectToLedgerApp() (sdkledger.SECP256K1, error) {
-	// Instantiate new Ledger object
-	ledger, err := usbwallet.NewLedgerHub()
+// Connect detects the hardware wallets and opens the one selected by the options,
+// after checking that the Ethereum app is running on it. Unlike the derivation
+// functions, which connect lazily on the first request of the keyring, Connect can
+// be called at startup to fail fast if no Ledger is usable. Calling it again
+// connects to the device anew, like every call of the derivation functions.
+func (e *EvmosSECP256K1) Connect() (sdkledger.SECP256K1, error) {
+	return e.ConnectWithContext(context.Background())
+}
+
+// TryConnect behaves like Connect, but reports whether a hardware wallet was found
+// instead of failing with ErrNoDevice when none is attached, or none passes the
+// device filter, e.g. for a startup screen probing for a Ledger without showing an
+// error. The error is only set if a device was found but could not be connected
+// to, in which case false is returned as well.
+func (e *EvmosSECP256K1) TryConnect() (sdkledger.SECP256K1, bool, error) {
+	device, err := e.Connect()
+	if errors.Is(err, ErrNoDevice) {
+		return nil, false, nil
+	}
 	if err != nil {
+		return nil, false, err
+	}
+
+	return device, true, nil
+}
+
+// ConnectWithContext behaves like Connect, but stops detecting and opening the
+// hardware wallet once the provided context is done, in which case ctx.Err() is
+// returned.
+func (e *EvmosSECP256K1) ConnectWithContext(ctx context.Context) (sdkledger.SECP256K1, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.connect(ctx, ""); err != nil {
 		return nil, err
 	}
 
-	if ledger == nil {
-		return nil, errors.New("no hardware wallets detected")
+	return e, nil
+}
+
+// connect instantiates a new hub and opens the wallet matching preferredURL, falling
+// back to the first wallet passing the device filter, or to the wallet chosen by
+// the device selector if multiple wallets are detected, or to the wallet at the
+// configured index if neither is set, if the URL is empty or not found. ctx.Err()
+// is returned if the context is done before the wallet is opened. If the device is
+// on the dashboard, the Ethereum app is launched if enabled (see SetAutoOpenApp).
+//
+// Note, connect assumes the lock is held!
+func (e *EvmosSECP256K1) connect(ctx context.Context, preferredURL string) error {
+	return e.connectWallet(ctx, preferredURL, e.autoOpenApp)
+}
+
+// connectWallet implements connect, launching the Ethereum app if the device is on
+// the dashboard and launchApp is set.
+//
+// Note, connectWallet assumes the lock is held!
+func (e *EvmosSECP256K1) connectWallet(ctx context.Context, preferredURL string, launchApp bool) error {
+	ledger, wallets, err := detectWallets(ctx, e.transport, e.connectTimeout)
+	if err != nil {
+		return err
 	}
 
 	e.Hub = ledger
-	wallets := e.Wallets()
+	e.Hub.SetRefreshInterval(e.refreshInterval)
+	e.Hub.SetCommandTimeout(e.commandTimeout)
+	e.Hub.SetAPDURecorder(e.apduRecorder)
+	e.log().Debugf("Detected %d hardware wallet(s)", len(wallets))
 
 	// No wallets detected; throw an error
 	if len(wallets) == 0 {
-		return nil, errors.New("no hardware wallets detected")
+		return ErrNoDevice
+	}
+
+	var primaryWallet accounts.Wallet
+	for _, wallet := range wallets {
+		if preferredURL != "" && wallet.URL().String() == preferredURL {
+			primaryWallet = wallet
+			break
+		}
 	}
 
-	// Default to use first wallet found
-	primaryWallet := wallets[0]
+	if primaryWallet == nil && e.deviceFilter != nil {
+		for i, wallet := range wallets {
+			if e.deviceFilter(newWalletInfo(i, wallet)) {
+				primaryWallet = wallet
+				break
+			}
+		}
 
-	// Open wallet for the first time. Unlike with other cases, we want to handle the error here.
-	if err := primaryWallet.Open(""); err != nil {
-		return nil, err
+		if primaryWallet == nil {
+			return fmt.Errorf("%w: none of the %d hardware wallet(s) detected matches the device filter", ErrNoDevice, len(wallets))
+		}
+	}
+
+	if primaryWallet == nil && e.deviceSelector != nil && len(wallets) > 1 {
+		infos := make([]WalletInfo, len(wallets))
+		for i, wallet := range wallets {
+			infos[i] = newWalletInfo(i, wallet)
+		}
+
+		index, err := e.deviceSelector(infos)
+		if err != nil {
+			return fmt.Errorf("unable to select a hardware wallet: %w", err)
+		}
+
+		if index < 0 || index >= len(wallets) {
+			return fmt.Errorf("no hardware wallet found at selected index %d (%d detected)", index, len(wallets))
+		}
+
+		primaryWallet = wallets[index]
+	}
+
+	if primaryWallet == nil {
+		if e.walletIndex < 0 || e.walletIndex >= len(wallets) {
+			return fmt.Errorf("no hardware wallet found at index %d (%d detected)", e.walletIndex, len(wallets))
+		}
+
+		// Use the requested wallet, which defaults to the first one found
+		primaryWallet = wallets[e.walletIndex]
+	}
+
+	err = e.openAndVerifyWallet(ctx, primaryWallet)
+	if errors.Is(err, ErrAppNotOpen) && launchApp {
+		return e.launchEthereumApp(ctx, primaryWallet)
+	}
+	if err != nil {
+		return err
 	}
 
 	e.PrimaryWallet = primaryWallet
+	e.closed = false
+	e.clearCache()
 
-	return e, nil
+	return nil
+}
+
+// openAndVerifyWallet opens the wallet and checks that the Ethereum app is running,
+// closing the wallet otherwise. Since the device can stop responding, ctx.Err() is
+// returned as soon as the context is done, and the wallet is closed once the device
+// eventually replies.
+//
+// Note, openAndVerifyWallet assumes the lock is held!
+func (e *EvmosSECP256K1) openAndVerifyWallet(ctx context.Context, wallet accounts.Wallet) error {
+	// Buffered so the goroutine can exit once the device replies, even after the
+	// context is done
+	errCh := make(chan error, 1)
+
+	go func() {
+		// Open wallet for the first time. Unlike with other cases, we want to handle the error here.
+		if err := e.openWallet(ctx, wallet); err != nil {
+			errCh <- err
+			return
+		}
+
+		// Make sure the user opened the Ethereum app, rather than another app or the dashboard
+		if err := verifyEthereumApp(wallet); err != nil {
+			//#nosec G703 -- the wallet is not usable, so a failure to close it is not relevant
+			_ = wallet.Close()
+			errCh <- err
+			return
+		}
+
+		errCh <- nil
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		go func() {
+			if err := <-errCh; err == nil {
+				//#nosec G703 -- the wallet is abandoned, so a failure to close it is not relevant
+				_ = wallet.Close()
+			}
+		}()
+		return ctx.Err()
+	}
+}
+
+// detectWallets instantiates a new hub reaching the devices through the given
+// transport, or USB HID if nil, and lists the hardware wallets it detects. Since
+// the USB enumeration can stall, ErrDetectionTimeout is returned if it does not
+// complete within the given timeout, unless the timeout is zero, and ctx.Err() is
+// returned if the context is done first.
+func detectWallets(ctx context.Context, transport usbwallet.Transport, timeout time.Duration) (*usbwallet.Hub, []accounts.Wallet, error) {
+	type detectResult struct {
+		hub     *usbwallet.Hub
+		wallets []accounts.Wallet
+		err     error
+	}
+
+	// Buffered so the goroutine can exit once the enumeration completes, even after
+	// the timeout expired
+	resultCh := make(chan detectResult, 1)
+
+	go func() {
+		// Instantiate new Ledger object
+		var (
+			ledger *usbwallet.Hub
+			err    error
+		)
+		if transport != nil {
+			ledger, err = usbwallet.NewLedgerHubWithTransport(transport)
+		} else {
+			ledger, err = usbwallet.NewLedgerHub()
+		}
+		if err != nil {
+			resultCh <- detectResult{err: err}
+			return
+		}
+
+		if ledger == nil {
+			resultCh <- detectResult{err: ErrNoDevice}
+			return
+		}
+
+		resultCh <- detectResult{hub: ledger, wallets: ledger.Wallets()}
+	}()
+
+	// A nil channel never fires, so there is no timeout unless set
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	select {
+	case res := <-resultCh:
+		return res.hub, res.wallets, res.err
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case <-timeoutCh:
+		return nil, nil, fmt.Errorf("%w: no device detected after %s", ErrDetectionTimeout, timeout)
+	}
 }
 
 // bytesToHexString is a helper function to convert a slice of bytes to a
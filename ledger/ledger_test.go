@@ -1,71 +1,1638 @@
 package ledger_test
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	gethaccounts "github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/evmos/evmos-ledger-go/accounts"
 	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos-ledger-go/usbwallet"
 	"github.com/evmos/evmos/v14/app"
 	"github.com/evmos/evmos/v14/encoding"
 	"github.com/evmos/evmos/v14/ethereum/eip712"
+	"github.com/stretchr/testify/mock"
 )
 
-// Test Mnemonic:
-// glow spread dentist swamp people siren hint muscle first sausage castle metal cycle abandon accident logic again around mix dial knee organ episode usual
+// Test Mnemonic:
+// glow spread dentist swamp people siren hint muscle first sausage castle metal cycle abandon accident logic again around mix dial knee organ episode usual
+
+// Load encoding config for sign doc encoding/decoding
+func init() {
+	config := encoding.MakeConfig(app.ModuleBasics)
+	eip712.SetEncodingConfig(config)
+	sdk.GetConfig().SetBech32PrefixForAccount("cosmos", "")
+}
+
+func (suite *LedgerTestSuite) TestEvmosLedgerDerivation() {
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - no hardware wallets detected",
+			func() {},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			derivationFunc := ledger.EvmosLedgerDerivation()
+			_, err := derivationFunc()
+			if tc.expPass {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+// bridgeTransport is a usbwallet.Transport listing the given devices, which fails
+// to open them.
+type bridgeTransport struct {
+	devices []usbwallet.DeviceInfo
+	err     error
+	opened  []string
+}
+
+func (t *bridgeTransport) Enumerate(uint16) ([]usbwallet.DeviceInfo, error) {
+	return t.devices, t.err
+}
+
+func (t *bridgeTransport) Open(info usbwallet.DeviceInfo) (io.ReadWriteCloser, error) {
+	t.opened = append(t.opened, info.Path)
+	return nil, errBridgeUnreachable
+}
+
+var errBridgeUnreachable = errors.New("bridge unreachable")
+
+func (suite *LedgerTestSuite) TestEvmosLedgerDerivationWithTransport() {
+	testCases := []struct {
+		name      string
+		transport *bridgeTransport
+		expErr    error
+		expOpened []string
+	}{
+		{
+			"fail - enumeration failed",
+			&bridgeTransport{err: errors.New("bridge enumeration failed")},
+			ledger.ErrNoDevice,
+			nil,
+		},
+		{
+			"fail - no hardware wallets detected",
+			&bridgeTransport{},
+			ledger.ErrNoDevice,
+			nil,
+		},
+		{
+			"fail - device opened through the transport",
+			&bridgeTransport{
+				devices: []usbwallet.DeviceInfo{{Path: "tcp://127.0.0.1:9999", ProductID: 0x4015}},
+			},
+			errBridgeUnreachable,
+			[]string{"tcp://127.0.0.1:9999"},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			derivationFunc := ledger.EvmosLedgerDerivationWithOptions(ledger.WithTransport(tc.transport))
+			_, err := derivationFunc()
+			suite.Require().Error(err)
+			if tc.expErr != nil {
+				suite.Require().ErrorIs(err, tc.expErr)
+			}
+			suite.Require().Equal(tc.expOpened, tc.transport.opened)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestEvmosLedgerDerivationWithDeviceFilter() {
+	devices := []usbwallet.DeviceInfo{
+		{Path: "tcp://127.0.0.1:9998", ProductID: 0x1015, Product: "Nano S"},
+		{Path: "tcp://127.0.0.1:9999", ProductID: 0x4015, Product: "Nano X"},
+	}
+
+	testCases := []struct {
+		name      string
+		filter    func(ledger.WalletInfo) bool
+		expErr    error
+		expOpened []string
+	}{
+		{
+			"fail - no device matches the filter",
+			func(info ledger.WalletInfo) bool {
+				return info.Product == "Nano S Plus"
+			},
+			ledger.ErrNoDevice,
+			nil,
+		},
+		{
+			"fail - matching device opened",
+			func(info ledger.WalletInfo) bool {
+				return info.Product == "Nano X"
+			},
+			errBridgeUnreachable,
+			[]string{"tcp://127.0.0.1:9999"},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			transport := &bridgeTransport{devices: devices}
+			derivationFunc := ledger.EvmosLedgerDerivationWithOptions(
+				ledger.WithTransport(transport),
+				ledger.WithDeviceFilter(tc.filter),
+			)
+			_, err := derivationFunc()
+			suite.Require().ErrorIs(err, tc.expErr)
+			suite.Require().Equal(tc.expOpened, transport.opened)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestEvmosLedgerDerivationWithDeviceSelector() {
+	errAborted := errors.New("selection aborted")
+	nanoS := usbwallet.DeviceInfo{Path: "tcp://127.0.0.1:9998", ProductID: 0x1015, Product: "Nano S"}
+	nanoX := usbwallet.DeviceInfo{Path: "tcp://127.0.0.1:9999", ProductID: 0x4015, Product: "Nano X"}
+
+	testCases := []struct {
+		name        string
+		devices     []usbwallet.DeviceInfo
+		selectIndex int
+		selectErr   error
+		expSelected bool
+		expErr      error
+		expOpened   []string
+	}{
+		{
+			"fail - selection aborted",
+			[]usbwallet.DeviceInfo{nanoS, nanoX},
+			0,
+			errAborted,
+			true,
+			errAborted,
+			nil,
+		},
+		{
+			"fail - selected index out of range",
+			[]usbwallet.DeviceInfo{nanoS, nanoX},
+			2,
+			nil,
+			true,
+			nil,
+			nil,
+		},
+		{
+			"fail - selected device opened",
+			[]usbwallet.DeviceInfo{nanoS, nanoX},
+			1,
+			nil,
+			true,
+			errBridgeUnreachable,
+			[]string{"tcp://127.0.0.1:9999"},
+		},
+		{
+			"fail - selector skipped for a single device",
+			[]usbwallet.DeviceInfo{nanoX},
+			1,
+			nil,
+			false,
+			errBridgeUnreachable,
+			[]string{"tcp://127.0.0.1:9999"},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			transport := &bridgeTransport{devices: tc.devices}
+
+			selected := false
+			derivationFunc := ledger.EvmosLedgerDerivationWithOptions(
+				ledger.WithTransport(transport),
+				ledger.WithDeviceSelector(func(infos []ledger.WalletInfo) (int, error) {
+					selected = true
+					suite.Require().Len(infos, len(tc.devices))
+					return tc.selectIndex, tc.selectErr
+				}),
+			)
+			_, err := derivationFunc()
+			suite.Require().Error(err)
+			if tc.expErr != nil {
+				suite.Require().ErrorIs(err, tc.expErr)
+			}
+			suite.Require().Equal(tc.expSelected, selected)
+			suite.Require().Equal(tc.expOpened, transport.opened)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestEvmosLedgerDerivationWithConnectTimeout() {
+	testCases := []struct {
+		name    string
+		timeout time.Duration
+		expErr  error
+	}{
+		{
+			"fail - detection timed out",
+			time.Nanosecond,
+			ledger.ErrDetectionTimeout,
+		},
+		{
+			"fail - no hardware wallets detected before the timeout",
+			time.Minute,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			derivationFunc := ledger.EvmosLedgerDerivationForDevice(0, ledger.WithConnectTimeout(tc.timeout))
+			_, err := derivationFunc()
+			suite.Require().Error(err)
+			if tc.expErr != nil {
+				suite.Require().ErrorIs(err, tc.expErr)
+			} else {
+				suite.Require().NotErrorIs(err, ledger.ErrDetectionTimeout)
+			}
+		})
+	}
+}
+
+// stallingTransport is a usbwallet.Transport whose enumeration, or the devices it
+// lists, never respond until released.
+type stallingTransport struct {
+	devices []usbwallet.DeviceInfo
+	release chan struct{}
+	opened  atomic.Pointer[stallingDevice] // Last device opened
+}
+
+func (t *stallingTransport) Enumerate(uint16) ([]usbwallet.DeviceInfo, error) {
+	if t.devices == nil {
+		<-t.release
+	}
+	return t.devices, nil
+}
+
+func (t *stallingTransport) Open(usbwallet.DeviceInfo) (io.ReadWriteCloser, error) {
+	device := &stallingDevice{release: t.release}
+	t.opened.Store(device)
+	return device, nil
+}
+
+// stallingDevice accepts every request but never replies until released. It records
+// whether it was closed while a read was blocked, which frees a HID handle in use.
+type stallingDevice struct {
+	release            chan struct{}
+	reading            atomic.Bool
+	closed             atomic.Bool
+	closedWhileReading atomic.Bool
+}
+
+func (d *stallingDevice) Write(p []byte) (int, error) { return len(p), nil }
+
+func (d *stallingDevice) Read([]byte) (int, error) {
+	d.reading.Store(true)
+	defer d.reading.Store(false)
+
+	<-d.release
+	return 0, io.EOF
+}
+
+func (d *stallingDevice) Close() error {
+	if d.reading.Load() {
+		d.closedWhileReading.Store(true)
+	}
+	d.closed.Store(true)
+	return nil
+}
+
+// slowDevice replies to every command with a reply spread over many HID reports,
+// each received after the given delay, so that the whole reply takes longer than
+// any single report.
+type slowDevice struct {
+	delay time.Duration
+	index uint16
+}
+
+func (d *slowDevice) Write(p []byte) (int, error) {
+	d.index = 0
+	return len(p), nil
+}
+
+func (d *slowDevice) Read(p []byte) (int, error) {
+	time.Sleep(d.delay)
+
+	report := make([]byte, 64)
+	copy(report, []byte{0x01, 0x01, 0x05})
+	binary.BigEndian.PutUint16(report[3:], d.index)
+	if d.index == 0 {
+		binary.BigEndian.PutUint16(report[5:], 256) // Length of the reply
+	}
+	d.index++
+	return copy(p, report), nil
+}
+
+func (d *slowDevice) Close() error { return nil }
+
+// slowTransport lists a single device replying with slowDevice.
+type slowTransport struct {
+	delay time.Duration
+}
+
+func (t slowTransport) Enumerate(uint16) ([]usbwallet.DeviceInfo, error) {
+	return []usbwallet.DeviceInfo{{Path: "tcp://127.0.0.1:9999", ProductID: 0x4015}}, nil
+}
+
+func (t slowTransport) Open(usbwallet.DeviceInfo) (io.ReadWriteCloser, error) {
+	return &slowDevice{delay: t.delay}, nil
+}
+
+func (suite *LedgerTestSuite) TestEvmosLedgerDerivationWithContext() {
+	testCases := []struct {
+		name    string
+		devices []usbwallet.DeviceInfo
+	}{
+		{
+			"fail - detection cancelled",
+			nil,
+		},
+		{
+			"fail - wallet open cancelled",
+			[]usbwallet.DeviceInfo{{Path: "tcp://127.0.0.1:9999", ProductID: 0x4015}},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			transport := &stallingTransport{devices: tc.devices, release: make(chan struct{})}
+			defer close(transport.release)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			derivationFunc := ledger.EvmosLedgerDerivationWithContext(ctx, ledger.WithTransport(transport))
+			_, err := derivationFunc()
+			suite.Require().ErrorIs(err, context.DeadlineExceeded)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestConnect() {
+	testCases := []struct {
+		name      string
+		transport *bridgeTransport
+		expErr    error
+	}{
+		{
+			"fail - no hardware wallets detected",
+			&bridgeTransport{},
+			ledger.ErrNoDevice,
+		},
+		{
+			"fail - device unreachable",
+			&bridgeTransport{
+				devices: []usbwallet.DeviceInfo{{Path: "tcp://127.0.0.1:9999", ProductID: 0x4015}},
+			},
+			errBridgeUnreachable,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			// Creating the instance doesn't reach the device
+			evmosSECP256K1 := ledger.NewEvmosSECP256K1(ledger.WithTransport(tc.transport))
+			suite.Require().Nil(evmosSECP256K1.Wallet())
+			suite.Require().Empty(tc.transport.opened)
+
+			_, err := evmosSECP256K1.Connect()
+			suite.Require().ErrorIs(err, tc.expErr)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestTryConnect() {
+	testCases := []struct {
+		name      string
+		transport *bridgeTransport
+		expErr    error
+	}{
+		{
+			"pass - no hardware wallets detected",
+			&bridgeTransport{},
+			nil,
+		},
+		{
+			"fail - device unreachable",
+			&bridgeTransport{
+				devices: []usbwallet.DeviceInfo{{Path: "tcp://127.0.0.1:9999", ProductID: 0x4015}},
+			},
+			errBridgeUnreachable,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			device, found, err := ledger.NewEvmosSECP256K1(ledger.WithTransport(tc.transport)).TryConnect()
+			suite.Require().Nil(device)
+			suite.Require().False(found)
+			if tc.expErr != nil {
+				suite.Require().ErrorIs(err, tc.expErr)
+				return
+			}
+
+			suite.Require().NoError(err)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestCommandTimeout() {
+	transport := &stallingTransport{
+		devices: []usbwallet.DeviceInfo{{Path: "tcp://127.0.0.1:9999", ProductID: 0x4015}},
+		release: make(chan struct{}),
+	}
+
+	// The device is detected, but never replies to the commands sent when opening it
+	evmosSECP256K1 := ledger.NewEvmosSECP256K1(
+		ledger.WithTransport(transport),
+		ledger.WithCommandTimeout(50*time.Millisecond),
+	)
+	_, err := evmosSECP256K1.Connect()
+	suite.Require().ErrorIs(err, ledger.ErrCommandTimeout)
+
+	// The device is only closed once the pending read returns
+	device := transport.opened.Load()
+	suite.Require().NotNil(device)
+	suite.Require().False(device.closed.Load())
+
+	close(transport.release)
+	suite.Require().Eventually(device.closed.Load, time.Second, time.Millisecond)
+	suite.Require().False(device.closedWhileReading.Load())
+}
+
+func (suite *LedgerTestSuite) TestCommandTimeoutWholeReply() {
+	// Each report is received within the timeout, but not the whole reply
+	evmosSECP256K1 := ledger.NewEvmosSECP256K1(
+		ledger.WithTransport(slowTransport{delay: 20 * time.Millisecond}),
+		ledger.WithCommandTimeout(50*time.Millisecond),
+	)
+	_, err := evmosSECP256K1.Connect()
+	suite.Require().ErrorIs(err, ledger.ErrCommandTimeout)
+}
+
+// rejectingDevice replies to every command with the "CLA not supported" status word,
+// as a Ledger does when the Ethereum app is not running.
+type rejectingDevice struct {
+	pending bool
+}
+
+func (d *rejectingDevice) Write(p []byte) (int, error) {
+	d.pending = true
+	return len(p), nil
+}
+
+func (d *rejectingDevice) Read(p []byte) (int, error) {
+	if !d.pending {
+		return 0, io.EOF
+	}
+	d.pending = false
+
+	reply := make([]byte, 64)
+	copy(reply, []byte{0x01, 0x01, 0x05, 0x00, 0x00, 0x00, 0x02, 0x6e, 0x00})
+	return copy(p, reply), nil
+}
+
+func (d *rejectingDevice) Close() error { return nil }
+
+// rejectingTransport lists a single device replying with rejectingDevice.
+type rejectingTransport struct{}
+
+func (rejectingTransport) Enumerate(uint16) ([]usbwallet.DeviceInfo, error) {
+	return []usbwallet.DeviceInfo{{Path: "tcp://127.0.0.1:9999", ProductID: 0x4015}}, nil
+}
+
+func (rejectingTransport) Open(usbwallet.DeviceInfo) (io.ReadWriteCloser, error) {
+	return &rejectingDevice{}, nil
+}
+
+func (suite *LedgerTestSuite) TestAPDURecorder() {
+	testCases := []struct {
+		name       string
+		redact     bool
+		expCommand string
+	}{
+		{
+			"pass - full transcript",
+			false,
+			"=> e002000015058000002c8000003c800000000000000000000000\n",
+		},
+		{
+			"pass - redacted transcript",
+			true,
+			"=> e002000015[redacted 21 bytes]\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			var transcript bytes.Buffer
+			evmosSECP256K1 := ledger.NewEvmosSECP256K1(
+				ledger.WithTransport(rejectingTransport{}),
+				ledger.WithAPDURecorder(&transcript, tc.redact),
+			)
+			device, err := evmosSECP256K1.Connect()
+			if err == nil {
+				suite.Require().NoError(device.Close())
+			}
+
+			// The address derivation sent when opening the wallet is recorded with its reply
+			suite.Require().Contains(transcript.String(), tc.expCommand+"<= 6e00\n")
+		})
+	}
+}
+
+// serveSpeculos emulates the Speculos APDU server on a local port, running the app
+// with the given name, and returns its endpoint. The Ethereum app replies to the
+// address derivations with the given public key and to the app configuration
+// requests, while the dashboard ("BOLOS") replies to the open app requests with the
+// given status word, launching the requested app on success.
+func (suite *LedgerTestSuite) serveSpeculos(pubKey *ecdsa.PublicKey, app string, openAppStatus []byte) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	suite.T().Cleanup(func() { _ = listener.Close() })
+
+	pubKeyBz := crypto.FromECDSAPub(pubKey)
+	address := hex.EncodeToString(crypto.PubkeyToAddress(*pubKey).Bytes())
+
+	// The running app is shared by the connections, since the device reconnects
+	// once an app is launched
+	var mu sync.Mutex
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				for {
+					var header [4]byte
+					if _, err := io.ReadFull(conn, header[:]); err != nil {
+						return
+					}
+					command := make([]byte, binary.BigEndian.Uint32(header[:]))
+					if _, err := io.ReadFull(conn, command); err != nil {
+						return
+					}
+
+					var data []byte
+					status := []byte{0x90, 0x00}
+
+					mu.Lock()
+					switch {
+					case command[0] == 0xb0 && command[1] == 0x01: // Get running app
+						data = append(append([]byte{0x01, byte(len(app))}, app...), 5)
+						data = append(data, "1.0.0"...)
+					case app == "BOLOS" && command[1] == 0xd8: // Open app
+						status = openAppStatus
+						if bytes.Equal(status, []byte{0x90, 0x00}) {
+							app = string(command[5:])
+						}
+					case app == "BOLOS":
+						status = []byte{0x6e, 0x00}
+					case command[1] == 0x02: // Retrieve address
+						data = append(append([]byte{byte(len(pubKeyBz))}, pubKeyBz...), byte(len(address)))
+						data = append(data, address...)
+					case command[1] == 0x06: // Get app configuration
+						data = []byte{0x00, 0x01, 0x0a, 0x00}
+					default:
+						status = []byte{0x6d, 0x00}
+					}
+					mu.Unlock()
+
+					reply := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+					reply = append(append(reply, data...), status...)
+					if _, err := conn.Write(reply); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func (suite *LedgerTestSuite) TestSpeculosEndpoint() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name     string
+		endpoint func() string
+		expPass  bool
+	}{
+		{
+			"fail - emulator unreachable",
+			func() string {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				suite.Require().NoError(err)
+				suite.Require().NoError(listener.Close())
+				return listener.Addr().String()
+			},
+			false,
+		},
+		{
+			"pass - public key derived by the emulator",
+			func() string {
+				return suite.serveSpeculos(&privKey.PublicKey, "Ethereum", nil)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			evmosSECP256K1 := ledger.NewEvmosSECP256K1(ledger.WithSpeculosEndpoint(tc.endpoint()))
+			device, err := evmosSECP256K1.Connect()
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			defer device.Close()
+
+			// The reply spans multiple HID reports
+			pubKey, err := device.GetPublicKeySECP256K1(gethaccounts.DefaultBaseDerivationPath)
+			suite.Require().NoError(err)
+			suite.Require().Equal(crypto.FromECDSAPub(&privKey.PublicKey), pubKey)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestClose() {
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+		},
+		{
+			"pass - wallet closed successfully",
+			func() {
+				RegisterClose(suite.mockWallet)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			err := suite.ledger.Close()
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Nil(suite.ledger.PrimaryWallet)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestCloseIdempotent() {
+	testCases := []struct {
+		name     string
+		closeErr error
+	}{
+		{
+			"pass - closed twice",
+			nil,
+		},
+		{
+			"pass - wallet already closed",
+			gethaccounts.ErrWalletClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			suite.mockWallet.On("Close").Return(tc.closeErr)
+
+			suite.Require().NoError(suite.ledger.Close())
+			suite.Require().NoError(suite.ledger.Close())
+			suite.Require().Nil(suite.ledger.PrimaryWallet)
+			suite.mockWallet.AssertNumberOfCalls(suite.T(), "Close", 1)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestCloseAfterFailedReconnect() {
+	RegisterURL(suite.mockWallet, gethaccounts.URL{Scheme: usbwallet.LedgerScheme, Path: "tcp://127.0.0.1:9999"})
+	RegisterClose(suite.mockWallet)
+	ledger.WithTransport(&bridgeTransport{})(suite.ledger)
+
+	suite.Require().ErrorIs(suite.ledger.Reconnect(), ledger.ErrNoDevice)
+	suite.Require().Nil(suite.ledger.PrimaryWallet)
+
+	// The wallet was released by Reconnect, so a deferred Close has nothing to do
+	suite.Require().NoError(suite.ledger.Close())
+	suite.mockWallet.AssertNumberOfCalls(suite.T(), "Close", 1)
+}
+
+func (suite *LedgerTestSuite) TestCloseTimeout() {
+	testCases := []struct {
+		name    string
+		timeout time.Duration
+		stall   bool
+		expErr  error
+	}{
+		{
+			"fail - wedged device",
+			50 * time.Millisecond,
+			true,
+			ledger.ErrCloseTimeout,
+		},
+		{
+			"pass - closed in time",
+			time.Second,
+			false,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			ledger.WithCloseTimeout(tc.timeout)(suite.ledger)
+
+			release := make(chan time.Time)
+			defer close(release)
+			call := suite.mockWallet.On("Close").Return(nil)
+			if tc.stall {
+				call.WaitUntil(release)
+			}
+
+			err := suite.ledger.Close()
+			if tc.expErr != nil {
+				suite.Require().ErrorIs(err, tc.expErr)
+			} else {
+				suite.Require().NoError(err)
+			}
+
+			// The references are released even if the device didn't close in time
+			suite.Require().Nil(suite.ledger.PrimaryWallet)
+			suite.Require().NoError(suite.ledger.Close())
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestOpen() {
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+		},
+		{
+			"fail - device can't be opened",
+			func() {
+				RegisterOpenError(suite.mockWallet, errors.New("hidapi: failed to open device"))
+			},
+			false,
+		},
+		{
+			"pass - wallet already open",
+			func() {
+				RegisterOpenError(suite.mockWallet, gethaccounts.ErrWalletAlreadyOpen)
+			},
+			true,
+		},
+		{
+			"pass - wallet opened successfully",
+			func() {
+				RegisterOpen(suite.mockWallet)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			err := suite.ledger.Open()
+			if tc.expPass {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestIsOpen() {
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expOpen  bool
+	}{
+		{
+			"closed - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+		},
+		{
+			"closed - wallet reported as closed",
+			func() {
+				RegisterStatus(suite.mockWallet, usbwallet.StatusClosed)
+			},
+			false,
+		},
+		{
+			"open - Ethereum app online",
+			func() {
+				RegisterStatus(suite.mockWallet, "Ethereum app v1.10.2 online")
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			suite.Require().Equal(tc.expOpen, suite.ledger.IsOpen())
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestWallet() {
+	suite.Require().Equal(suite.mockWallet, suite.ledger.Wallet())
+
+	suite.ledger.PrimaryWallet = nil
+	suite.Require().Nil(suite.ledger.Wallet())
+}
+
+func (suite *LedgerTestSuite) TestOpenErrorsSurface() {
+	suite.ledger.SetRetry(1, 0)
+	RegisterOpenError(suite.mockWallet, errors.New("hidapi: failed to open device"))
+
+	_, err := suite.ledger.GetPublicKeySECP256K1(gethaccounts.DefaultBaseDerivationPath)
+	suite.Require().ErrorContains(err, "could not open Ledger")
+
+	_, _, err = suite.ledger.GetAddressPubKeySECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.hrp)
+	suite.Require().ErrorContains(err, "could not open Ledger")
+
+	_, err = suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+	suite.Require().ErrorContains(err, "could not open Ledger")
+
+	// The device is never queried if the wallet could not be opened
+	suite.mockWallet.AssertNotCalled(suite.T(), "Derive", mock.Anything, mock.Anything)
+}
+
+func (suite *LedgerTestSuite) TestDeviceStatus() {
+	testCases := []struct {
+		name     string
+		mockFunc func()
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+		},
+		{
+			"fail - no hardware wallet hub",
+			func() {
+				suite.ledger.Hub = nil
+			},
+		},
+		{
+			"fail - device disconnected",
+			func() {
+				RegisterURL(suite.mockWallet, gethaccounts.URL{Scheme: "ledger", Path: "unplugged"})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			_, err := suite.ledger.DeviceStatus()
+			suite.Require().Error(err)
+			suite.Require().False(suite.ledger.DeviceConnected())
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestReconnect() {
+	testCases := []struct {
+		name     string
+		mockFunc func()
+	}{
+		{
+			"fail - no wallet to close, no hardware wallets detected",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+		},
+		{
+			"fail - previous wallet closed, no hardware wallets detected",
+			func() {
+				RegisterURL(suite.mockWallet, gethaccounts.URL{Scheme: "ledger", Path: "unplugged"})
+				RegisterClose(suite.mockWallet)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			err := suite.ledger.Reconnect()
+			suite.Require().ErrorContains(err, "no hardware wallets detected")
+			suite.Require().Nil(suite.ledger.PrimaryWallet)
+			suite.mockWallet.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestConcurrentRequests() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	var overlapped atomic.Bool
+	RegisterOpen(suite.mockWallet)
+	RegisterDeriveExclusive(suite.mockWallet, addr, &privKey.PublicKey, &overlapped)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := suite.ledger.GetPublicKeySECP256K1(gethaccounts.DefaultBaseDerivationPath)
+			suite.Require().NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	suite.Require().False(overlapped.Load(), "wallet requests must be serialized")
+}
+
+func (suite *LedgerTestSuite) TestCoalescedDerivations() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	RegisterOpen(suite.mockWallet)
+	suite.mockWallet.On("Derive", gethaccounts.DefaultBaseDerivationPath, true).
+		Run(func(mock.Arguments) {
+			close(started)
+			<-release
+		}).
+		Return(accounts.Account{Address: addr, PublicKey: &privKey.PublicKey}, nil).
+		Once()
+
+	// The same path without the hardened markers, which are added before deriving
+	unhardenedPath := []uint32{44, 60, 0, 0, 0}
+
+	var wg sync.WaitGroup
+	request := func(getAddress bool, hdPath []uint32) {
+		defer wg.Done()
+		if getAddress {
+			_, _, err := suite.ledger.GetAddressPubKeySECP256K1(hdPath, suite.hrp)
+			suite.Require().NoError(err)
+			return
+		}
+		_, err := suite.ledger.GetPublicKeySECP256K1(hdPath)
+		suite.Require().NoError(err)
+	}
+
+	// Wait for the first derivation to reach the device before sending the others
+	wg.Add(1)
+	go request(false, gethaccounts.DefaultBaseDerivationPath)
+	<-started
+
+	for i := 0; i < 10; i++ {
+		hdPath := []uint32(gethaccounts.DefaultBaseDerivationPath)
+		if i%3 == 0 {
+			hdPath = unhardenedPath
+		}
+
+		wg.Add(1)
+		go request(i%2 == 0, hdPath)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	suite.mockWallet.AssertNumberOfCalls(suite.T(), "Derive", 1)
+}
+
+func (suite *LedgerTestSuite) TestSignatures() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	testCases := []struct {
+		name     string
+		tx       []byte
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			suite.txAmino,
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			suite.txAmino,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			false,
+		},
+		{
+			"fail - error generating signature",
+			suite.txAmino,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedDataError(suite.mockWallet, account, suite.txAmino)
+			},
+			false,
+		},
+		{
+			"pass - test ledger amino signature",
+			suite.txAmino,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+			true,
+		},
+		{
+			"pass - test ledger protobuf signature",
+			suite.txProtobuf,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedData(suite.mockWallet, account, suite.txProtobuf)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			_, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, tc.tx)
+			if tc.expPass {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestSignTypedData() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	// Typed data built natively by a dApp, without a Cosmos sign doc
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			"Mail": {
+				{Name: "from", Type: "address"},
+				{Name: "contents", Type: "string"},
+			},
+		},
+		PrimaryType: "Mail",
+		Domain: apitypes.TypedDataDomain{
+			Name:    "Evmos Mail",
+			Version: "1",
+			ChainId: math.NewHexOrDecimal256(9001),
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":     addr.Hex(),
+			"contents": "Hello, Evmos!",
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			false,
+		},
+		{
+			"pass - typed data signed",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedDataObject(suite.mockWallet, account, typedData)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			logger := &recordingLogger{}
+			suite.ledger.SetLogger(logger)
+			suite.ledger.SetDisplayEIP712Hashes(true)
+			tc.mockFunc()
+
+			signature, err := suite.ledger.SignTypedData(gethaccounts.DefaultBaseDerivationPath, typedData)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Len(signature, crypto.SignatureLength)
+
+			// The EIP-712 hashes are still displayed
+			suite.Require().Len(logger.infos, 3)
+			suite.Require().Equal("Signing the following payload with EIP-712:", logger.infos[0])
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestSignaturesWithContext() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	testCases := []struct {
+		name     string
+		ctxFunc  func() (context.Context, context.CancelFunc)
+		mockFunc func()
+		expErr   error
+	}{
+		{
+			"fail - context already cancelled",
+			func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			func() {},
+			context.Canceled,
+		},
+		{
+			"fail - deadline exceeded while waiting for the device",
+			func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedDataDelay(suite.mockWallet, account, suite.txAmino, 200*time.Millisecond)
+			},
+			context.DeadlineExceeded,
+		},
+		{
+			"pass - signed before the deadline",
+			func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Minute)
+			},
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			ctx, cancel := tc.ctxFunc()
+			defer cancel()
+			_, err := suite.ledger.SignSECP256K1WithContext(ctx, gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+			if tc.expErr == nil {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().ErrorIs(err, tc.expErr)
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestAbortCurrentOperation() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	message := []byte("Sign in to Evmos")
+	chainID := big.NewInt(9001)
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, Gas: 21000, GasPrice: big.NewInt(1)})
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		signFunc func() error
+		abort    bool
+		expErr   error
+	}{
+		{
+			"fail - SignSECP256K1 aborted while waiting for the device",
+			func() {
+				RegisterSignTypedDataDelay(suite.mockWallet, account, suite.txAmino, time.Second)
+			},
+			func() error {
+				_, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+				return err
+			},
+			true,
+			ledger.ErrAborted,
+		},
+		{
+			"fail - SignPersonalMessage aborted while waiting for the device",
+			func() {
+				RegisterSignTextDelay(suite.mockWallet, account, message, time.Second)
+			},
+			func() error {
+				_, err := suite.ledger.SignPersonalMessage(gethaccounts.DefaultBaseDerivationPath, message)
+				return err
+			},
+			true,
+			ledger.ErrAborted,
+		},
+		{
+			"fail - SignTx aborted while waiting for the device",
+			func() {
+				RegisterSignTxDelay(suite.mockWallet, account, tx, chainID, time.Second)
+			},
+			func() error {
+				_, err := suite.ledger.SignTx(gethaccounts.DefaultBaseDerivationPath, tx, chainID)
+				return err
+			},
+			true,
+			ledger.ErrAborted,
+		},
+		{
+			"fail - SignBatch aborted while waiting for the device",
+			func() {
+				RegisterSignTypedDataDelay(suite.mockWallet, account, suite.txAmino, time.Second)
+			},
+			func() error {
+				signatures, err := suite.ledger.SignBatch(gethaccounts.DefaultBaseDerivationPath, [][]byte{suite.txAmino, suite.txAmino})
+				suite.Require().Nil(signatures)
+				return err
+			},
+			true,
+			ledger.ErrAborted,
+		},
+		{
+			"pass - nothing to abort",
+			func() {
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+			func() error {
+				_, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+				return err
+			},
+			false,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			RegisterOpen(suite.mockWallet)
+			RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			tc.mockFunc()
+
+			if tc.abort {
+				go func() {
+					time.Sleep(20 * time.Millisecond)
+					suite.ledger.AbortCurrentOperation()
+				}()
+			} else {
+				// Aborting before the request doesn't affect it
+				suite.ledger.AbortCurrentOperation()
+			}
+
+			start := time.Now()
+			err := tc.signFunc()
+			if tc.expErr == nil {
+				suite.Require().NoError(err)
+				return
+			}
+
+			suite.Require().ErrorIs(err, tc.expErr)
+			suite.Require().Less(time.Since(start), time.Second)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestSetLogger() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	logger := &recordingLogger{}
+	suite.ledger.SetLogger(logger)
+	suite.ledger.SetDisplayEIP712Hashes(true)
+
+	RegisterOpen(suite.mockWallet)
+	RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+	RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+
+	_, err = suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+	suite.Require().NoError(err)
+
+	suite.Require().Len(logger.infos, 4)
+	suite.Require().Equal("Generating payload, please check your Ledger...", logger.infos[0])
+	suite.Require().Equal("Signing the following payload with EIP-712:", logger.infos[1])
+	suite.Require().Contains(logger.infos[2], "- Domain: 0x")
+	suite.Require().Contains(logger.infos[3], "- Message: 0x")
+}
+
+func (suite *LedgerTestSuite) TestTypedDataBuilder() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+			},
+			"Tx": {
+				{Name: "memo", Type: "string"},
+			},
+		},
+		PrimaryType: "Tx",
+		Domain: apitypes.TypedDataDomain{
+			Name: "Custom Chain",
+		},
+		Message: apitypes.TypedDataMessage{
+			"memo": "custom",
+		},
+	}
+	builderErr := errors.New("unsupported sign doc")
+
+	testCases := []struct {
+		name     string
+		builder  ledger.TypedDataBuilder
+		mockFunc func()
+		expErr   error
+	}{
+		{
+			"pass - custom builder",
+			func(signDocBytes []byte) (apitypes.TypedData, error) {
+				suite.Require().Equal([]byte("custom sign doc"), signDocBytes)
+				return typedData, nil
+			},
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedDataObject(suite.mockWallet, account, typedData)
+			},
+			nil,
+		},
+		{
+			"fail - custom builder error",
+			func([]byte) (apitypes.TypedData, error) {
+				return apitypes.TypedData{}, builderErr
+			},
+			func() {},
+			builderErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			ledger.WithTypedDataBuilder(tc.builder)(suite.ledger)
+			tc.mockFunc()
+
+			signature, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, []byte("custom sign doc"))
+			if tc.expErr != nil {
+				suite.Require().ErrorIs(err, tc.expErr)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Len(signature, crypto.SignatureLength)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestPromptWriter() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
 
-// Load encoding config for sign doc encoding/decoding
-func init() {
-	config := encoding.MakeConfig(app.ModuleBasics)
-	eip712.SetEncodingConfig(config)
-	sdk.GetConfig().SetBech32PrefixForAccount("cosmos", "")
+	logger := &recordingLogger{}
+	var prompts bytes.Buffer
+	suite.ledger.SetLogger(logger)
+	ledger.WithPromptWriter(&prompts)(suite.ledger)
+	suite.ledger.SetDisplayEIP712Hashes(true)
+
+	RegisterOpen(suite.mockWallet)
+	RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+	RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+
+	_, err = suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+	suite.Require().NoError(err)
+
+	// Prompts are written to the prompt writer only, one per line
+	suite.Require().Empty(logger.infos)
+	lines := strings.Split(strings.TrimSuffix(prompts.String(), "\n"), "\n")
+	suite.Require().Len(lines, 4)
+	suite.Require().Equal("Generating payload, please check your Ledger...", lines[0])
+	suite.Require().Equal("Signing the following payload with EIP-712:", lines[1])
 }
 
-func (suite *LedgerTestSuite) TestEvmosLedgerDerivation() {
+func (suite *LedgerTestSuite) TestDisplayEIP712Hashes() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	typedData, err := eip712.GetEIP712TypedDataForMsg(suite.txAmino)
+	suite.Require().NoError(err)
+	domainHash, messageHash, err := ledger.ComputeEIP712Hashes(typedData)
+	suite.Require().NoError(err)
+	suite.Require().Len(domainHash, 32)
+	suite.Require().Len(messageHash, 32)
+
 	testCases := []struct {
 		name     string
-		mockFunc func()
-		expPass  bool
+		display  bool
+		expInfos int
 	}{
 		{
-			"fail - no hardware wallets detected",
-			func() {},
+			"hashes not displayed by default",
 			false,
+			1,
+		},
+		{
+			"hashes displayed when enabled",
+			true,
+			4,
 		},
 	}
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
 			suite.SetupTest() // reset
-			derivationFunc := ledger.EvmosLedgerDerivation()
-			_, err := derivationFunc()
-			if tc.expPass {
-				suite.Require().NoError(err)
-			} else {
-				suite.Require().Error(err)
+			logger := &recordingLogger{}
+			suite.ledger.SetLogger(logger)
+			suite.ledger.SetDisplayEIP712Hashes(tc.display)
+
+			RegisterOpen(suite.mockWallet)
+			RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+
+			_, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+			suite.Require().NoError(err)
+
+			suite.Require().Len(logger.infos, tc.expInfos)
+			if tc.display {
+				suite.Require().Equal("- Domain: 0x"+strings.ToUpper(hex.EncodeToString(domainHash)), logger.infos[2])
+				suite.Require().Equal("- Message: 0x"+strings.ToUpper(hex.EncodeToString(messageHash)), logger.infos[3])
 			}
 		})
 	}
 }
 
-func (suite *LedgerTestSuite) TestClose() {
+func (suite *LedgerTestSuite) TestSignatureEquivalence() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
 	testCases := []struct {
-		name     string
-		mockFunc func()
-		expPass  bool
+		name       string
+		txProtobuf []byte
+		txAmino    []byte
+		mockFunc   func()
+		expPass    bool
 	}{
 		{
-			"fail - can't find Ledger device",
-			func() {
-				suite.ledger.PrimaryWallet = nil
-			},
-			false,
-		},
-		{
-			"pass - wallet closed successfully",
+			"pass - signatures are equivalent",
+			suite.txProtobuf,
+			suite.txAmino,
 			func() {
-				RegisterClose(suite.mockWallet)
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedData(suite.mockWallet, account, suite.txProtobuf)
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
 			},
 			true,
 		},
@@ -75,77 +1642,100 @@ func (suite *LedgerTestSuite) TestClose() {
 		suite.Run(tc.name, func() {
 			suite.SetupTest() // reset
 			tc.mockFunc()
-			err := suite.ledger.Close()
+			protoSignature, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, tc.txProtobuf)
+			suite.Require().NoError(err)
+			aminoSignature, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, tc.txAmino)
+			suite.Require().NoError(err)
 			if tc.expPass {
-				suite.Require().NoError(err)
+				suite.Require().Equal(protoSignature, aminoSignature)
 			} else {
-				suite.Require().Error(err)
+				suite.Require().NotEqual(protoSignature, aminoSignature)
 			}
 		})
 	}
 }
 
-func (suite *LedgerTestSuite) TestSignatures() {
+func (suite *LedgerTestSuite) TestGetAddressPubKeySECP256K1() {
 	privKey, err := crypto.GenerateKey()
 	suite.Require().NoError(err)
+
 	addr := crypto.PubkeyToAddress(privKey.PublicKey)
-	account := accounts.Account{
-		Address:   addr,
-		PublicKey: &privKey.PublicKey,
-	}
+	expAddr, err := sdk.Bech32ifyAddressBytes("evmos", common.HexToAddress(addr.String()).Bytes())
+	suite.Require().NoError(err)
 
 	testCases := []struct {
 		name     string
-		tx       []byte
-		mockFunc func()
 		expPass  bool
+		mockFunc func()
 	}{
 		{
 			"fail - can't find Ledger device",
-			suite.txAmino,
+			false,
 			func() {
 				suite.ledger.PrimaryWallet = nil
 			},
-			false,
 		},
 		{
 			"fail - unable to derive Ledger address",
-			suite.txAmino,
+			false,
 			func() {
 				RegisterOpen(suite.mockWallet)
 				RegisterDeriveError(suite.mockWallet)
 			},
-			false,
 		},
 		{
-			"fail - error generating signature",
-			suite.txAmino,
+			"fail - no public key returned by Ledger",
+			false,
 			func() {
 				RegisterOpen(suite.mockWallet)
-				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
-				RegisterSignTypedDataError(suite.mockWallet, account, suite.txAmino)
+				RegisterDerive(suite.mockWallet, addr, nil)
 			},
+		},
+		{
+			"fail - bech32 prefix empty",
 			false,
+			func() {
+				suite.hrp = ""
+			},
 		},
 		{
-			"pass - test ledger amino signature",
-			suite.txAmino,
+			"fail - bech32 prefix with mixed case",
+			false,
+			func() {
+				suite.hrp = "Evmos"
+			},
+		},
+		{
+			"fail - bech32 prefix with invalid character",
+			false,
+			func() {
+				suite.hrp = "ev mos"
+			},
+		},
+		{
+			"fail - bech32 prefix not allowed",
+			false,
 			func() {
+				suite.hrp = "evoms"
+				suite.ledger.SetAllowedHRPs("evmos", "cosmos")
+			},
+		},
+		{
+			"pass - bech32 prefix allowed",
+			true,
+			func() {
+				suite.ledger.SetAllowedHRPs("evmos", "cosmos")
 				RegisterOpen(suite.mockWallet)
 				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
-				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
 			},
-			true,
 		},
 		{
-			"pass - test ledger protobuf signature",
-			suite.txProtobuf,
+			"pass - get ledger address",
+			true,
 			func() {
 				RegisterOpen(suite.mockWallet)
 				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
-				RegisterSignTypedData(suite.mockWallet, account, suite.txProtobuf)
 			},
-			true,
 		},
 	}
 
@@ -153,41 +1743,59 @@ func (suite *LedgerTestSuite) TestSignatures() {
 		suite.Run(tc.name, func() {
 			suite.SetupTest() // reset
 			tc.mockFunc()
-			_, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, tc.tx)
+			_, addr, err := suite.ledger.GetAddressPubKeySECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.hrp)
 			if tc.expPass {
-				suite.Require().NoError(err)
+				suite.Require().NoError(err, "Could not get wallet address")
+				suite.Require().Equal(expAddr, addr)
 			} else {
 				suite.Require().Error(err)
 			}
+			suite.mockWallet.AssertExpectations(suite.T())
 		})
 	}
 }
 
-func (suite *LedgerTestSuite) TestSignatureEquivalence() {
+func (suite *LedgerTestSuite) TestGetAddressPubKeySECP256K1WithConfig() {
 	privKey, err := crypto.GenerateKey()
 	suite.Require().NoError(err)
+
 	addr := crypto.PubkeyToAddress(privKey.PublicKey)
-	account := accounts.Account{
-		Address:   addr,
-		PublicKey: &privKey.PublicKey,
-	}
+	expAddr, err := sdk.Bech32ifyAddressBytes("evmos", addr.Bytes())
+	suite.Require().NoError(err)
 
 	testCases := []struct {
-		name       string
-		txProtobuf []byte
-		txAmino    []byte
-		mockFunc   func()
-		expPass    bool
+		name     string
+		config   func() *sdk.Config
+		mockFunc func()
+		expPass  bool
 	}{
 		{
-			"pass - signatures are equivalent",
-			suite.txProtobuf,
-			suite.txAmino,
+			"fail - no config",
+			func() *sdk.Config { return nil },
+			func() {},
+			false,
+		},
+		{
+			"fail - empty account prefix",
+			func() *sdk.Config {
+				config := sdk.NewConfig()
+				config.SetBech32PrefixForAccount("", "")
+				return config
+			},
+			func() {},
+			false,
+		},
+		{
+			// The global config uses the "cosmos" prefix
+			"pass - account prefix of the config",
+			func() *sdk.Config {
+				config := sdk.NewConfig()
+				config.SetBech32PrefixForAccount("evmos", "evmospub")
+				return config
+			},
 			func() {
 				RegisterOpen(suite.mockWallet)
 				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
-				RegisterSignTypedData(suite.mockWallet, account, suite.txProtobuf)
-				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
 			},
 			true,
 		},
@@ -197,27 +1805,86 @@ func (suite *LedgerTestSuite) TestSignatureEquivalence() {
 		suite.Run(tc.name, func() {
 			suite.SetupTest() // reset
 			tc.mockFunc()
-			protoSignature, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, tc.txProtobuf)
-			suite.Require().NoError(err)
-			aminoSignature, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, tc.txAmino)
-			suite.Require().NoError(err)
+
+			_, addr, err := suite.ledger.GetAddressPubKeySECP256K1WithConfig(gethaccounts.DefaultBaseDerivationPath, tc.config())
 			if tc.expPass {
-				suite.Require().Equal(protoSignature, aminoSignature)
+				suite.Require().NoError(err)
+				suite.Require().Equal(expAddr, addr)
 			} else {
-				suite.Require().NotEqual(protoSignature, aminoSignature)
+				suite.Require().Error(err)
 			}
+			suite.mockWallet.AssertExpectations(suite.T())
 		})
 	}
 }
 
-func (suite *LedgerTestSuite) TestGetAddressPubKeySECP256K1() {
+func (suite *LedgerTestSuite) TestGetAddressPubKeySECP256K1WithDisplay() {
 	privKey, err := crypto.GenerateKey()
 	suite.Require().NoError(err)
 
 	addr := crypto.PubkeyToAddress(privKey.PublicKey)
-	expAddr, err := sdk.Bech32ifyAddressBytes("evmos", common.HexToAddress(addr.String()).Bytes())
+	expAddr, err := sdk.Bech32ifyAddressBytes("evmos", addr.Bytes())
 	suite.Require().NoError(err)
 
+	testCases := []struct {
+		name     string
+		display  bool
+		mockFunc func()
+		expErr   error
+		expPass  bool
+	}{
+		{
+			"fail - user rejected the displayed address",
+			true,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveWithDisplayRejected(suite.mockWallet)
+			},
+			ledger.ErrUserRejected,
+			false,
+		},
+		{
+			"pass - address confirmed on device",
+			true,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveWithDisplay(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			nil,
+			true,
+		},
+		{
+			"pass - address derived silently",
+			false,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			_, addr, err := suite.ledger.GetAddressPubKeySECP256K1WithDisplay(gethaccounts.DefaultBaseDerivationPath, suite.hrp, tc.display)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(expAddr, addr)
+			} else {
+				suite.Require().ErrorIs(err, tc.expErr)
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestGetPublicKeySECP256K1() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	expPubkeyBz := crypto.FromECDSAPub(&privKey.PublicKey)
 	testCases := []struct {
 		name     string
 		expPass  bool
@@ -239,16 +1906,15 @@ func (suite *LedgerTestSuite) TestGetAddressPubKeySECP256K1() {
 			},
 		},
 		{
-			"fail - bech32 prefix empty",
+			"fail - no public key returned by Ledger",
 			false,
 			func() {
-				suite.hrp = ""
 				RegisterOpen(suite.mockWallet)
-				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterDerive(suite.mockWallet, addr, nil)
 			},
 		},
 		{
-			"pass - get ledger address",
+			"pass - get ledger public key",
 			true,
 			func() {
 				RegisterOpen(suite.mockWallet)
@@ -261,10 +1927,10 @@ func (suite *LedgerTestSuite) TestGetAddressPubKeySECP256K1() {
 		suite.Run(tc.name, func() {
 			suite.SetupTest() // reset
 			tc.mockFunc()
-			_, addr, err := suite.ledger.GetAddressPubKeySECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.hrp)
+			pubKeyBz, err := suite.ledger.GetPublicKeySECP256K1(gethaccounts.DefaultBaseDerivationPath)
 			if tc.expPass {
 				suite.Require().NoError(err, "Could not get wallet address")
-				suite.Require().Equal(expAddr, addr)
+				suite.Require().Equal(expPubkeyBz, pubKeyBz)
 			} else {
 				suite.Require().Error(err)
 			}
@@ -272,11 +1938,11 @@ func (suite *LedgerTestSuite) TestGetAddressPubKeySECP256K1() {
 	}
 }
 
-func (suite *LedgerTestSuite) TestGetPublicKeySECP256K1() {
+func (suite *LedgerTestSuite) TestGetCompressedPublicKeySECP256K1() {
 	privKey, err := crypto.GenerateKey()
 	suite.Require().NoError(err)
 	addr := crypto.PubkeyToAddress(privKey.PublicKey)
-	expPubkeyBz := crypto.FromECDSAPub(&privKey.PublicKey)
+	expPubkeyBz := crypto.CompressPubkey(&privKey.PublicKey)
 	testCases := []struct {
 		name     string
 		expPass  bool
@@ -298,7 +1964,7 @@ func (suite *LedgerTestSuite) TestGetPublicKeySECP256K1() {
 			},
 		},
 		{
-			"pass - get ledger public key",
+			"pass - get compressed ledger public key",
 			true,
 			func() {
 				RegisterOpen(suite.mockWallet)
@@ -311,9 +1977,10 @@ func (suite *LedgerTestSuite) TestGetPublicKeySECP256K1() {
 		suite.Run(tc.name, func() {
 			suite.SetupTest() // reset
 			tc.mockFunc()
-			pubKeyBz, err := suite.ledger.GetPublicKeySECP256K1(gethaccounts.DefaultBaseDerivationPath)
+			pubKeyBz, err := suite.ledger.GetCompressedPublicKeySECP256K1(gethaccounts.DefaultBaseDerivationPath)
 			if tc.expPass {
 				suite.Require().NoError(err, "Could not get wallet address")
+				suite.Require().Len(pubKeyBz, 33)
 				suite.Require().Equal(expPubkeyBz, pubKeyBz)
 			} else {
 				suite.Require().Error(err)
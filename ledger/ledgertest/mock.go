@@ -0,0 +1,301 @@
+// Package ledgertest provides a software implementation of the Cosmos SDK Ledger
+// SECP256K1 interface, which allows testing code that depends on the ledger package
+// without a physical device.
+package ledgertest
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	sdkledger "github.com/cosmos/cosmos-sdk/crypto/ledger"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos/v14/ethereum/eip712"
+)
+
+// DefaultMnemonic is the mnemonic used to derive keys when none is configured.
+// It must never be used to hold real funds.
+const DefaultMnemonic = "glow spread dentist swamp people siren hint muscle first sausage castle metal cycle abandon accident logic again around mix dial knee organ episode usual"
+
+// ErrDeviceDisconnected is returned by every request while the mock device is disconnected.
+var ErrDeviceDisconnected = errors.New("ledger device disconnected")
+
+var _ sdkledger.SECP256K1 = &MockSECP256K1{}
+
+// Option configures a MockSECP256K1.
+type Option func(*MockSECP256K1)
+
+// WithMnemonic sets the mnemonic from which the keys of the mock device are derived.
+func WithMnemonic(mnemonic string) Option {
+	return func(m *MockSECP256K1) {
+		m.mnemonic = mnemonic
+	}
+}
+
+// WithPublicKey sets the uncompressed public key returned for the given HD path,
+// instead of the one derived from the mnemonic. Unless a canned address is set as
+// well, the address is computed from this public key.
+func WithPublicKey(hdPath []uint32, pubKey []byte) Option {
+	return func(m *MockSECP256K1) {
+		m.publicKeys[pathKey(hdPath)] = pubKey
+	}
+}
+
+// WithAddress sets the address returned for the given HD path, regardless of the
+// requested HRP.
+func WithAddress(hdPath []uint32, address string) Option {
+	return func(m *MockSECP256K1) {
+		m.addresses[pathKey(hdPath)] = address
+	}
+}
+
+// WithSignature sets the signature returned for every signing request, instead of
+// signing the EIP-712 payload with the key derived from the mnemonic.
+func WithSignature(signature []byte) Option {
+	return func(m *MockSECP256K1) {
+		m.signature = signature
+	}
+}
+
+// WithUserRejection makes every signing request fail with ledger.ErrUserRejected,
+// as if the user declined it on the device.
+func WithUserRejection() Option {
+	return func(m *MockSECP256K1) {
+		m.rejectSignatures = true
+	}
+}
+
+// WithDisconnected makes the mock device start in the disconnected state.
+func WithDisconnected() Option {
+	return func(m *MockSECP256K1) {
+		m.disconnected = true
+	}
+}
+
+// MockSECP256K1 implements the sdkledger.SECP256K1 interface with software keys
+// derived from a mnemonic. By default, it returns the same public keys, addresses
+// and EIP-712 signatures as a Ledger loaded with that mnemonic, and every value can
+// be overridden with canned data.
+//
+// Signing requires the EIP-712 encoding config to be set through
+// eip712.SetEncodingConfig, as is the case for the ledger package.
+type MockSECP256K1 struct {
+	mu sync.Mutex
+
+	mnemonic         string
+	keys             map[string]*ecdsa.PrivateKey
+	publicKeys       map[string][]byte
+	addresses        map[string]string
+	signature        []byte
+	rejectSignatures bool
+	disconnected     bool
+}
+
+// NewMockSECP256K1 returns a connected mock device configured with the given options.
+func NewMockSECP256K1(opts ...Option) *MockSECP256K1 {
+	m := &MockSECP256K1{
+		mnemonic:   DefaultMnemonic,
+		keys:       make(map[string]*ecdsa.PrivateKey),
+		publicKeys: make(map[string][]byte),
+		addresses:  make(map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Derivation returns a derivation function that yields the mock device, so that it
+// can be used in place of ledger.EvmosLedgerDerivation.
+func (m *MockSECP256K1) Derivation() ledger.Secp256k1DerivationFn {
+	return func() (sdkledger.SECP256K1, error) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if m.disconnected {
+			return nil, ErrDeviceDisconnected
+		}
+
+		return m, nil
+	}
+}
+
+// Disconnect simulates unplugging the device. All requests fail with
+// ErrDeviceDisconnected until Connect is called.
+func (m *MockSECP256K1) Disconnect() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.disconnected = true
+}
+
+// Connect simulates plugging the device back in.
+func (m *MockSECP256K1) Connect() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.disconnected = false
+}
+
+// SetUserRejection sets whether signing requests are declined by the simulated user.
+func (m *MockSECP256K1) SetUserRejection(reject bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rejectSignatures = reject
+}
+
+// Close implements sdkledger.SECP256K1. The mock device can still be used after it
+// is closed, like the Ledger wallet which is re-opened on every request.
+func (m *MockSECP256K1) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.disconnected {
+		return ErrDeviceDisconnected
+	}
+
+	return nil
+}
+
+// GetPublicKeySECP256K1 implements sdkledger.SECP256K1.
+func (m *MockSECP256K1) GetPublicKeySECP256K1(hdPath []uint32) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.disconnected {
+		return nil, ErrDeviceDisconnected
+	}
+
+	return m.publicKey(hdPath)
+}
+
+// GetAddressPubKeySECP256K1 implements sdkledger.SECP256K1.
+func (m *MockSECP256K1) GetAddressPubKeySECP256K1(hdPath []uint32, hrp string) ([]byte, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.disconnected {
+		return nil, "", ErrDeviceDisconnected
+	}
+
+	pubKeyBz, err := m.publicKey(hdPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if address, ok := m.addresses[pathKey(hdPath)]; ok {
+		return pubKeyBz, address, nil
+	}
+
+	pubKey, err := crypto.UnmarshalPubkey(pubKeyBz)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid public key for path %s: %w", pathKey(hdPath), err)
+	}
+
+	address, err := sdk.Bech32ifyAddressBytes(hrp, crypto.PubkeyToAddress(*pubKey).Bytes())
+	if err != nil {
+		return nil, "", err
+	}
+
+	return pubKeyBz, address, nil
+}
+
+// SignSECP256K1 implements sdkledger.SECP256K1. The signature is returned in the
+// 65-byte [R || S || V] format, where V is 27 or 28, like the Ledger does.
+func (m *MockSECP256K1) SignSECP256K1(hdPath []uint32, signDocBytes []byte) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.disconnected {
+		return nil, ErrDeviceDisconnected
+	}
+
+	if m.rejectSignatures {
+		return nil, fmt.Errorf("error generating signature, please retry: %w", ledger.ErrUserRejected)
+	}
+
+	if m.signature != nil {
+		return append([]byte(nil), m.signature...), nil
+	}
+
+	typedData, err := eip712.GetEIP712TypedDataForMsg(signDocBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate EIP-712 hash for object: %w", err)
+	}
+
+	key, err := m.privateKey(hdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := crypto.Sign(hash, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add 27 to match the Ledger format
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return signature, nil
+}
+
+// publicKey returns the canned public key for the given path, or the one derived
+// from the mnemonic.
+//
+// Note, publicKey assumes the lock is held!
+func (m *MockSECP256K1) publicKey(hdPath []uint32) ([]byte, error) {
+	if pubKey, ok := m.publicKeys[pathKey(hdPath)]; ok {
+		return pubKey, nil
+	}
+
+	key, err := m.privateKey(hdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.FromECDSAPub(&key.PublicKey), nil
+}
+
+// privateKey derives the private key for the given path from the mnemonic.
+//
+// Note, privateKey assumes the lock is held!
+func (m *MockSECP256K1) privateKey(hdPath []uint32) (*ecdsa.PrivateKey, error) {
+	path := pathKey(hdPath)
+	if key, ok := m.keys[path]; ok {
+		return key, nil
+	}
+
+	keyBz, err := hd.Secp256k1.Derive()(m.mnemonic, "", path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive key for path %s: %w", path, err)
+	}
+
+	key, err := crypto.ToECDSA(keyBz)
+	if err != nil {
+		return nil, err
+	}
+
+	m.keys[path] = key
+
+	return key, nil
+}
+
+// pathKey returns the textual representation of the HD path (e.g. "m/44'/60'/0'/0/0").
+func pathKey(hdPath []uint32) string {
+	return gethaccounts.DerivationPath(hdPath).String()
+}
@@ -0,0 +1,241 @@
+package ledgertest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos-ledger-go/ledger/ledgertest"
+	"github.com/evmos/evmos/v14/app"
+	"github.com/evmos/evmos/v14/encoding"
+	"github.com/evmos/evmos/v14/ethereum/eip712"
+)
+
+// Load encoding config for sign doc encoding/decoding
+func init() {
+	config := encoding.MakeConfig(app.ModuleBasics)
+	eip712.SetEncodingConfig(config)
+	sdk.GetConfig().SetBech32PrefixForAccount("cosmos", "")
+}
+
+const signDoc = `{"account_number":"0","chain_id":"evmos_9000-1","fee":{"amount":[{"amount":"150","denom":"atom"}],"gas":"20000"},"memo":"memo","msgs":[{"type":"cosmos-sdk/MsgSend","value":{"amount":[{"amount":"150","denom":"atom"}],"from_address":"cosmos1r5sckdd808qvg7p8d0auaw896zcluqfd7djffp","to_address":"cosmos10t8ca2w09ykd6ph0agdz5stvgau47whhaggl9a"}}],"sequence":"6"}`
+
+type MockTestSuite struct {
+	suite.Suite
+	hdPath []uint32
+}
+
+func TestMockTestSuite(t *testing.T) {
+	suite.Run(t, new(MockTestSuite))
+}
+
+func (suite *MockTestSuite) SetupTest() {
+	suite.hdPath = gethaccounts.DefaultBaseDerivationPath
+}
+
+func (suite *MockTestSuite) TestDerivation() {
+	mock := ledgertest.NewMockSECP256K1()
+
+	device, err := mock.Derivation()()
+	suite.Require().NoError(err)
+	suite.Require().Equal(mock, device)
+
+	mock.Disconnect()
+	_, err = mock.Derivation()()
+	suite.Require().ErrorIs(err, ledgertest.ErrDeviceDisconnected)
+}
+
+func (suite *MockTestSuite) TestGetAddressPubKeySECP256K1() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	pubKeyBz := crypto.FromECDSAPub(&privKey.PublicKey)
+	expAddr, err := sdk.Bech32ifyAddressBytes("evmos", crypto.PubkeyToAddress(privKey.PublicKey).Bytes())
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name      string
+		opts      []ledgertest.Option
+		expPubKey []byte
+		expAddr   string
+	}{
+		{
+			"pass - canned public key",
+			[]ledgertest.Option{ledgertest.WithPublicKey(suite.hdPath, pubKeyBz)},
+			pubKeyBz,
+			expAddr,
+		},
+		{
+			"pass - canned public key and address",
+			[]ledgertest.Option{
+				ledgertest.WithPublicKey(suite.hdPath, pubKeyBz),
+				ledgertest.WithAddress(suite.hdPath, "evmos1canned"),
+			},
+			pubKeyBz,
+			"evmos1canned",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			mock := ledgertest.NewMockSECP256K1(tc.opts...)
+
+			pubKey, err := mock.GetPublicKeySECP256K1(suite.hdPath)
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expPubKey, pubKey)
+
+			pubKey, addr, err := mock.GetAddressPubKeySECP256K1(suite.hdPath, "evmos")
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expPubKey, pubKey)
+			suite.Require().Equal(tc.expAddr, addr)
+		})
+	}
+}
+
+func (suite *MockTestSuite) TestDerivedKeys() {
+	mock := ledgertest.NewMockSECP256K1()
+
+	pubKey, err := mock.GetPublicKeySECP256K1(suite.hdPath)
+	suite.Require().NoError(err)
+
+	// Keys are deterministic for a given mnemonic and path
+	samePubKey, err := ledgertest.NewMockSECP256K1().GetPublicKeySECP256K1(suite.hdPath)
+	suite.Require().NoError(err)
+	suite.Require().Equal(pubKey, samePubKey)
+
+	otherPath := gethaccounts.DerivationPath{0x80000000 + 44, 0x80000000 + 60, 0x80000000 + 0, 0, 1}
+	otherPubKey, err := mock.GetPublicKeySECP256K1(otherPath)
+	suite.Require().NoError(err)
+	suite.Require().NotEqual(pubKey, otherPubKey)
+
+	otherMnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	otherPubKey, err = ledgertest.NewMockSECP256K1(ledgertest.WithMnemonic(otherMnemonic)).GetPublicKeySECP256K1(suite.hdPath)
+	suite.Require().NoError(err)
+	suite.Require().NotEqual(pubKey, otherPubKey)
+}
+
+func (suite *MockTestSuite) TestSignSECP256K1() {
+	canned := make([]byte, crypto.SignatureLength)
+	canned[0] = 0x01
+
+	testCases := []struct {
+		name      string
+		opts      []ledgertest.Option
+		actionFn  func(mock *ledgertest.MockSECP256K1)
+		expPass   bool
+		expErr    error
+		expCanned bool
+	}{
+		{
+			"pass - signature verifies against the derived public key",
+			nil,
+			func(*ledgertest.MockSECP256K1) {},
+			true,
+			nil,
+			false,
+		},
+		{
+			"pass - canned signature",
+			[]ledgertest.Option{ledgertest.WithSignature(canned)},
+			func(*ledgertest.MockSECP256K1) {},
+			true,
+			nil,
+			true,
+		},
+		{
+			"pass - reconnected",
+			nil,
+			func(mock *ledgertest.MockSECP256K1) {
+				mock.Disconnect()
+				mock.Connect()
+			},
+			true,
+			nil,
+			false,
+		},
+		{
+			"fail - rejected by user",
+			[]ledgertest.Option{ledgertest.WithUserRejection()},
+			func(*ledgertest.MockSECP256K1) {},
+			false,
+			ledger.ErrUserRejected,
+			false,
+		},
+		{
+			"fail - rejection enabled at runtime",
+			nil,
+			func(mock *ledgertest.MockSECP256K1) {
+				mock.SetUserRejection(true)
+			},
+			false,
+			ledger.ErrUserRejected,
+			false,
+		},
+		{
+			"fail - started disconnected",
+			[]ledgertest.Option{ledgertest.WithDisconnected()},
+			func(*ledgertest.MockSECP256K1) {},
+			false,
+			ledgertest.ErrDeviceDisconnected,
+			false,
+		},
+		{
+			"fail - disconnected",
+			nil,
+			func(mock *ledgertest.MockSECP256K1) {
+				mock.Disconnect()
+			},
+			false,
+			ledgertest.ErrDeviceDisconnected,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			mock := ledgertest.NewMockSECP256K1(tc.opts...)
+			tc.actionFn(mock)
+
+			signature, err := mock.SignSECP256K1(suite.hdPath, []byte(signDoc))
+			if !tc.expPass {
+				suite.Require().ErrorIs(err, tc.expErr)
+				return
+			}
+
+			suite.Require().NoError(err)
+			if tc.expCanned {
+				suite.Require().Equal(canned, signature)
+				return
+			}
+
+			suite.verifySignature(mock, signature)
+		})
+	}
+}
+
+func (suite *MockTestSuite) verifySignature(mock *ledgertest.MockSECP256K1, signature []byte) {
+	suite.T().Helper()
+
+	suite.Require().Len(signature, crypto.SignatureLength)
+	suite.Require().Contains([]byte{27, 28}, signature[crypto.RecoveryIDOffset])
+
+	typedData, err := eip712.GetEIP712TypedDataForMsg([]byte(signDoc))
+	suite.Require().NoError(err)
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	suite.Require().NoError(err)
+
+	sig := append([]byte(nil), signature...)
+	sig[crypto.RecoveryIDOffset] -= 27
+
+	recovered, err := crypto.Ecrecover(hash, sig)
+	suite.Require().NoError(err)
+
+	pubKey, err := mock.GetPublicKeySECP256K1(suite.hdPath)
+	suite.Require().NoError(err)
+	suite.Require().Equal(pubKey, recovered)
+}
@@ -0,0 +1,42 @@
+package ledger
+
+import "fmt"
+
+// Logger defines the interface used to report progress and diagnostic messages
+// while interacting with the Ledger device.
+type Logger interface {
+	// Infof logs messages that are relevant for the end user, e.g. a reminder to
+	// check the device or the hashes that are about to be signed, unless they are
+	// directed to a prompt writer (see EvmosSECP256K1.SetPromptWriter).
+	Infof(format string, args ...interface{})
+
+	// Debugf logs diagnostic messages that are useful when troubleshooting.
+	Debugf(format string, args ...interface{})
+}
+
+var (
+	_ Logger = StdoutLogger{}
+	_ Logger = NopLogger{}
+)
+
+// StdoutLogger is the default Logger. It prints informational messages to
+// stdout and discards debug messages.
+type StdoutLogger struct{}
+
+// Infof implements Logger, printing the formatted message followed by a newline
+// to stdout.
+func (StdoutLogger) Infof(format string, args ...interface{}) {
+	fmt.Printf(format+"\n", args...)
+}
+
+// Debugf implements Logger, discarding the message.
+func (StdoutLogger) Debugf(string, ...interface{}) {}
+
+// NopLogger is a Logger that discards every message.
+type NopLogger struct{}
+
+// Infof implements Logger, discarding the message.
+func (NopLogger) Infof(string, ...interface{}) {}
+
+// Debugf implements Logger, discarding the message.
+func (NopLogger) Debugf(string, ...interface{}) {}
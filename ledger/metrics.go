@@ -0,0 +1,129 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/evmos/evmos-ledger-go/usbwallet"
+)
+
+// Kinds of errors reported to Metrics.
+const (
+	ErrorKindRejected   = "rejected"     // The user declined the request on the device
+	ErrorKindLocked     = "locked"       // The device is locked
+	ErrorKindAppNotOpen = "app_not_open" // The Ethereum app is not open
+	ErrorKindWrongApp   = "wrong_app"    // Another app is open
+	ErrorKindBusy       = "busy"         // The device is held by another application
+	ErrorKindIO         = "io"           // The USB communication failed
+	ErrorKindCanceled   = "canceled"     // The request was canceled or timed out
+	ErrorKindOther      = "other"        // Any other failure
+)
+
+// Metrics receives measurements of the requests sent to the Ledger, e.g. to export
+// them to Prometheus and alert when the device latency spikes or the rejections
+// climb. The methods are called synchronously, so they must not block.
+type Metrics interface {
+	// ObserveSignDuration records the duration of a signing request, including the
+	// time waiting for the user to confirm it on the device.
+	ObserveSignDuration(d time.Duration)
+
+	// IncSignError counts a failed signing request, by kind of error (e.g.
+	// ErrorKindRejected).
+	IncSignError(kind string)
+
+	// ObserveDeriveDuration records the duration of an account derivation.
+	ObserveDeriveDuration(d time.Duration)
+
+	// IncDeriveError counts a failed account derivation, by kind of error.
+	IncDeriveError(kind string)
+}
+
+var _ Metrics = NopMetrics{}
+
+// NopMetrics is the default Metrics, discarding every measurement.
+type NopMetrics struct{}
+
+// ObserveSignDuration implements Metrics.
+func (NopMetrics) ObserveSignDuration(time.Duration) {}
+
+// IncSignError implements Metrics.
+func (NopMetrics) IncSignError(string) {}
+
+// ObserveDeriveDuration implements Metrics.
+func (NopMetrics) ObserveDeriveDuration(time.Duration) {}
+
+// IncDeriveError implements Metrics.
+func (NopMetrics) IncDeriveError(string) {}
+
+// SetMetrics sets the Metrics receiving the measurements of the signing and
+// derivation requests. Passing nil restores the default, which discards them.
+func (e *EvmosSECP256K1) SetMetrics(metrics Metrics) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.metrics = metrics
+}
+
+// ErrorKind classifies an error returned by the Ledger into one of the kinds
+// reported to Metrics.
+func ErrorKind(err error) string {
+	switch {
+	case errors.Is(err, ErrUserRejected):
+		return ErrorKindRejected
+	case errors.Is(err, ErrDeviceLocked):
+		return ErrorKindLocked
+	case errors.Is(err, ErrAppNotOpen):
+		return ErrorKindAppNotOpen
+	case errors.Is(err, ErrWrongApp):
+		return ErrorKindWrongApp
+	case errors.Is(err, ErrDeviceBusy):
+		return ErrorKindBusy
+	case errors.Is(err, usbwallet.ErrDeviceIO), errors.Is(err, ErrShortResponse):
+		return ErrorKindIO
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return ErrorKindCanceled
+	default:
+		return ErrorKindOther
+	}
+}
+
+// getMetrics returns the configured metrics, falling back to NopMetrics.
+//
+// Note, getMetrics assumes the lock is held!
+func (e *EvmosSECP256K1) getMetrics() Metrics {
+	if e.metrics == nil {
+		return NopMetrics{}
+	}
+	return e.metrics
+}
+
+// observeSign runs the signing request and reports it to the metrics.
+//
+// Note, observeSign assumes the lock is held!
+func (e *EvmosSECP256K1) observeSign(sign func() error) error {
+	start := time.Now()
+	err := sign()
+
+	e.getMetrics().ObserveSignDuration(time.Since(start))
+	if err != nil {
+		e.getMetrics().IncSignError(ErrorKind(err))
+	}
+
+	return err
+}
+
+// observeDerive runs the derivation request and reports it to the metrics.
+//
+// Note, observeDerive assumes the lock is held!
+func (e *EvmosSECP256K1) observeDerive(derive func() error) error {
+	start := time.Now()
+	err := derive()
+
+	e.getMetrics().ObserveDeriveDuration(time.Since(start))
+	if err != nil {
+		e.getMetrics().IncDeriveError(ErrorKind(err))
+	}
+
+	return err
+}
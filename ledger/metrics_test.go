@@ -0,0 +1,129 @@
+package ledger_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos-ledger-go/usbwallet"
+)
+
+// recordingMetrics is a ledger.Metrics recording the measurements it receives.
+type recordingMetrics struct {
+	signDurations   []time.Duration
+	signErrors      []string
+	deriveDurations []time.Duration
+	deriveErrors    []string
+}
+
+func (m *recordingMetrics) ObserveSignDuration(d time.Duration) {
+	m.signDurations = append(m.signDurations, d)
+}
+
+func (m *recordingMetrics) IncSignError(kind string) {
+	m.signErrors = append(m.signErrors, kind)
+}
+
+func (m *recordingMetrics) ObserveDeriveDuration(d time.Duration) {
+	m.deriveDurations = append(m.deriveDurations, d)
+}
+
+func (m *recordingMetrics) IncDeriveError(kind string) {
+	m.deriveErrors = append(m.deriveErrors, kind)
+}
+
+func (suite *LedgerTestSuite) TestMetrics() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	testCases := []struct {
+		name            string
+		mockFunc        func()
+		expSigns        int
+		expSignErrors   []string
+		expDeriveErrors []string
+	}{
+		{
+			"derivation failed",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveAPDUError(suite.mockWallet, 0x5515)
+			},
+			0,
+			nil,
+			[]string{ledger.ErrorKindLocked},
+		},
+		{
+			"signature rejected",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedDataRejected(suite.mockWallet, account, suite.txAmino)
+			},
+			1,
+			[]string{ledger.ErrorKindRejected},
+			nil,
+		},
+		{
+			"signature generated",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+			1,
+			nil,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			metrics := &recordingMetrics{}
+			ledger.WithMetrics(metrics)(suite.ledger)
+			tc.mockFunc()
+
+			_, _ = suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+
+			suite.Require().Len(metrics.deriveDurations, 1)
+			suite.Require().Equal(tc.expDeriveErrors, metrics.deriveErrors)
+			suite.Require().Len(metrics.signDurations, tc.expSigns)
+			suite.Require().Equal(tc.expSignErrors, metrics.signErrors)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestErrorKind() {
+	testCases := []struct {
+		err     error
+		expKind string
+	}{
+		{&usbwallet.APDUError{StatusWord: 0x6985}, ledger.ErrorKindRejected},
+		{&usbwallet.APDUError{StatusWord: 0x5515}, ledger.ErrorKindLocked},
+		{&usbwallet.APDUError{StatusWord: 0x6d00}, ledger.ErrorKindAppNotOpen},
+		{&usbwallet.APDUError{StatusWord: 0x6e00}, ledger.ErrorKindWrongApp},
+		{fmt.Errorf("could not open Ledger: %w", ledger.ErrDeviceBusy), ledger.ErrorKindBusy},
+		{fmt.Errorf("%w: write failed", usbwallet.ErrDeviceIO), ledger.ErrorKindIO},
+		{fmt.Errorf("%w: reply lacks signature", usbwallet.ErrShortResponse), ledger.ErrorKindIO},
+		{context.DeadlineExceeded, ledger.ErrorKindCanceled},
+		{errors.New("unexpected"), ledger.ErrorKindOther},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.expKind, func() {
+			suite.Require().Equal(tc.expKind, ledger.ErrorKind(tc.err))
+		})
+	}
+}
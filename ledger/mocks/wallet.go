@@ -36,6 +36,27 @@ func (_m *Wallet) Accounts() []accounts.Account {
 	return r0
 }
 
+// AppConfiguration provides a mock function with given fields:
+func (_m *Wallet) AppConfiguration() (accounts.AppConfiguration, error) {
+	ret := _m.Called()
+
+	var r0 accounts.AppConfiguration
+	if rf, ok := ret.Get(0).(func() accounts.AppConfiguration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(accounts.AppConfiguration)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Close provides a mock function with given fields:
 func (_m *Wallet) Close() error {
 	ret := _m.Called()
@@ -85,6 +106,94 @@ func (_m *Wallet) Derive(path go_ethereumaccounts.DerivationPath, pin bool) (acc
 	return r0, r1
 }
 
+// DeriveWithChainCode provides a mock function with given fields: path
+func (_m *Wallet) DeriveWithChainCode(path go_ethereumaccounts.DerivationPath) (accounts.Account, []byte, error) {
+	ret := _m.Called(path)
+
+	var r0 accounts.Account
+	if rf, ok := ret.Get(0).(func(go_ethereumaccounts.DerivationPath) accounts.Account); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Get(0).(accounts.Account)
+	}
+
+	var r1 []byte
+	if rf, ok := ret.Get(1).(func(go_ethereumaccounts.DerivationPath) []byte); ok {
+		r1 = rf(path)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(go_ethereumaccounts.DerivationPath) error); ok {
+		r2 = rf(path)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeriveWithDisplay provides a mock function with given fields: path, pin
+func (_m *Wallet) DeriveWithDisplay(path go_ethereumaccounts.DerivationPath, pin bool) (accounts.Account, error) {
+	ret := _m.Called(path, pin)
+
+	var r0 accounts.Account
+	if rf, ok := ret.Get(0).(func(go_ethereumaccounts.DerivationPath, bool) accounts.Account); ok {
+		r0 = rf(path, pin)
+	} else {
+		r0 = ret.Get(0).(accounts.Account)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(go_ethereumaccounts.DerivationPath, bool) error); ok {
+		r1 = rf(path, pin)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Info provides a mock function with given fields:
+func (_m *Wallet) Info() accounts.DeviceInfo {
+	ret := _m.Called()
+
+	var r0 accounts.DeviceInfo
+	if rf, ok := ret.Get(0).(func() accounts.DeviceInfo); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(accounts.DeviceInfo)
+	}
+
+	return r0
+}
+
+// ListApps provides a mock function with given fields:
+func (_m *Wallet) ListApps() ([]accounts.AppInfo, error) {
+	ret := _m.Called()
+
+	var r0 []accounts.AppInfo
+	if rf, ok := ret.Get(0).(func() []accounts.AppInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]accounts.AppInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Open provides a mock function with given fields: passphrase
 func (_m *Wallet) Open(passphrase string) error {
 	ret := _m.Called(passphrase)
@@ -99,16 +208,74 @@ func (_m *Wallet) Open(passphrase string) error {
 	return r0
 }
 
+// OpenApp provides a mock function with given fields: name
+func (_m *Wallet) OpenApp(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RunningApp provides a mock function with given fields:
+func (_m *Wallet) RunningApp() (accounts.AppInfo, error) {
+	ret := _m.Called()
+
+	var r0 accounts.AppInfo
+	if rf, ok := ret.Get(0).(func() accounts.AppInfo); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(accounts.AppInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SignText provides a mock function with given fields: account, text
+func (_m *Wallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	ret := _m.Called(account, text)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(accounts.Account, []byte) []byte); ok {
+		r0 = rf(account, text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(accounts.Account, []byte) error); ok {
+		r1 = rf(account, text)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignTx provides a mock function with given fields: account, tx, chainID
-func (_m *Wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) ([]byte, error) {
+func (_m *Wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
 	ret := _m.Called(account, tx, chainID)
 
-	var r0 []byte
-	if rf, ok := ret.Get(0).(func(accounts.Account, *types.Transaction, *big.Int) []byte); ok {
+	var r0 *types.Transaction
+	if rf, ok := ret.Get(0).(func(accounts.Account, *types.Transaction, *big.Int) *types.Transaction); ok {
 		r0 = rf(account, tx, chainID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
+			r0 = ret.Get(0).(*types.Transaction)
 		}
 	}
 
@@ -145,6 +312,52 @@ func (_m *Wallet) SignTypedData(account accounts.Account, typedData apitypes.Typ
 	return r0, r1
 }
 
+// SignTypedDataFull provides a mock function with given fields: account, typedData, tokens
+func (_m *Wallet) SignTypedDataFull(account accounts.Account, typedData apitypes.TypedData, tokens []accounts.TokenInfo) ([]byte, error) {
+	ret := _m.Called(account, typedData, tokens)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(accounts.Account, apitypes.TypedData, []accounts.TokenInfo) []byte); ok {
+		r0 = rf(account, typedData, tokens)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(accounts.Account, apitypes.TypedData, []accounts.TokenInfo) error); ok {
+		r1 = rf(account, typedData, tokens)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SignTypedDataWithTokens provides a mock function with given fields: account, typedData, tokens
+func (_m *Wallet) SignTypedDataWithTokens(account accounts.Account, typedData apitypes.TypedData, tokens []accounts.TokenInfo) ([]byte, error) {
+	ret := _m.Called(account, typedData, tokens)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(accounts.Account, apitypes.TypedData, []accounts.TokenInfo) []byte); ok {
+		r0 = rf(account, typedData, tokens)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(accounts.Account, apitypes.TypedData, []accounts.TokenInfo) error); ok {
+		r1 = rf(account, typedData, tokens)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Status provides a mock function with given fields:
 func (_m *Wallet) Status() (string, error) {
 	ret := _m.Called()
@@ -0,0 +1,293 @@
+package ledger
+
+import (
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/usbwallet"
+)
+
+// Option configures the EvmosSECP256K1 returned by a derivation function (see
+// EvmosLedgerDerivationWithOptions).
+type Option func(*EvmosSECP256K1)
+
+// WithLogger sets the logger used to report progress and diagnostic messages (see
+// SetLogger).
+func WithLogger(logger Logger) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetLogger(logger)
+	}
+}
+
+// WithPromptWriter directs the interactive prompts meant for the user to the given
+// writer (see SetPromptWriter).
+func WithPromptWriter(w io.Writer) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetPromptWriter(w)
+	}
+}
+
+// WithPromptCallback sets a function that is called at each stage of the signing
+// requests, instead of showing the default prompt (see SetPromptCallback).
+func WithPromptCallback(callback func(stage Stage)) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetPromptCallback(callback)
+	}
+}
+
+// WithWalletIndex selects the hardware wallet found at the given index, as listed
+// by ListWallets, when multiple Ledgers are connected. The first wallet detected is
+// used by default.
+func WithWalletIndex(index int) Option {
+	return func(e *EvmosSECP256K1) {
+		e.walletIndex = index
+	}
+}
+
+// WithDeviceFilter selects the first hardware wallet passing the given filter when
+// multiple Ledgers are connected, e.g. the first Nano X or the device at a known
+// USB path. Unlike WithWalletIndex, the selection does not depend on the order in
+// which the devices are enumerated, which can change across reboots. ErrNoDevice
+// is returned if no wallet passes the filter. The filter takes precedence over
+// WithWalletIndex.
+func WithDeviceFilter(filter func(WalletInfo) bool) Option {
+	return func(e *EvmosSECP256K1) {
+		e.deviceFilter = filter
+	}
+}
+
+// WithDeviceSelector lets the given selector choose the hardware wallet to use when
+// multiple Ledgers are connected, e.g. so that a CLI tool prompts the user, instead
+// of silently using the first one. The selector receives the detected wallets, as
+// listed by ListWallets, and returns the index of the chosen one. It is skipped if
+// a single wallet is detected, so that the single-device case stays
+// non-interactive. An error returned by the selector aborts the connection. A
+// device filter set with WithDeviceFilter takes precedence, while the selector
+// takes precedence over WithWalletIndex.
+func WithDeviceSelector(selector func([]WalletInfo) (int, error)) Option {
+	return func(e *EvmosSECP256K1) {
+		e.deviceSelector = selector
+	}
+}
+
+// WithConnectTimeout bounds the time spent detecting the hardware wallets when
+// connecting, after which ErrDetectionTimeout is returned instead of blocking on a
+// stalled USB enumeration. A zero duration, the default, disables the timeout.
+func WithConnectTimeout(timeout time.Duration) Option {
+	return func(e *EvmosSECP256K1) {
+		e.connectTimeout = timeout
+	}
+}
+
+// WithRefreshInterval sets the time between the scans of the USB devices performed
+// while subscriptions are active (see SetRefreshInterval).
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetRefreshInterval(interval)
+	}
+}
+
+// WithCommandTimeout bounds the time spent on each APDU sent to the device, after
+// which ErrCommandTimeout is returned (see SetCommandTimeout).
+func WithCommandTimeout(timeout time.Duration) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetCommandTimeout(timeout)
+	}
+}
+
+// WithCloseTimeout bounds the time Close waits for the device to release the
+// connection, after which ErrCloseTimeout is returned (see SetCloseTimeout).
+func WithCloseTimeout(timeout time.Duration) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetCloseTimeout(timeout)
+	}
+}
+
+// WithAPDURecorder writes a transcript of the APDUs exchanged with the device to the
+// given writer, optionally redacting their payloads (see SetAPDURecorder).
+func WithAPDURecorder(w io.Writer, redact bool) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetAPDURecorder(w, redact)
+	}
+}
+
+// WithTransport makes the derivation function discover and connect to the Ledger
+// through the given transport, e.g. a bridge to a device which is not directly
+// accessible from a container, instead of USB HID.
+func WithTransport(transport usbwallet.Transport) Option {
+	return func(e *EvmosSECP256K1) {
+		e.transport = transport
+	}
+}
+
+// WithSpeculosEndpoint makes the derivation function connect to a Ledger emulated by
+// Speculos through its APDU server at the given TCP endpoint (e.g. "127.0.0.1:9999"),
+// so that integration tests can run without a physical device. It is a shorthand
+// for WithTransport with a usbwallet.SpeculosTransport.
+func WithSpeculosEndpoint(endpoint string) Option {
+	return WithTransport(usbwallet.NewSpeculosTransport(endpoint))
+}
+
+// WithAutoReopen enables or disables reopening the primary wallet at the start of
+// every request (see SetAutoReopen).
+func WithAutoReopen(enabled bool) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetAutoReopen(enabled)
+	}
+}
+
+// WithAutoOpenApp enables or disables launching the Ethereum app when connecting to
+// a device on the dashboard (see SetAutoOpenApp).
+func WithAutoOpenApp(enabled bool) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetAutoOpenApp(enabled)
+	}
+}
+
+// WithPassphraseProvider sets the function supplying the passphrase passed to the
+// wallet when it is opened. The provider is invoked lazily, each time the wallet
+// is actually opened, rather than once upfront. Note that the Ledger prompts for
+// its passphrases on the device itself, so the Ledger driver ignores the value.
+func WithPassphraseProvider(provider func() (string, error)) Option {
+	return func(e *EvmosSECP256K1) {
+		e.passphraseFn = provider
+	}
+}
+
+// WithRetry configures how device requests are retried upon transient USB
+// communication failures (see SetRetry).
+func WithRetry(attempts int, delay time.Duration) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetRetry(attempts, delay)
+	}
+}
+
+// WithCacheEnabled enables caching the derived accounts (see SetCacheEnabled).
+func WithCacheEnabled(enabled bool) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetCacheEnabled(enabled)
+	}
+}
+
+// WithAllowedHRPs restricts the human-readable prefixes accepted when encoding
+// addresses (see SetAllowedHRPs).
+func WithAllowedHRPs(hrps ...string) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetAllowedHRPs(hrps...)
+	}
+}
+
+// WithHRP sets the human-readable prefix used to encode the bech32 addresses
+// returned by GetAddresses (see SetHRP).
+func WithHRP(hrp string) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetHRP(hrp)
+	}
+}
+
+// WithRegisteredHRP registers the human-readable prefix of the addresses of the
+// given chain ID (see RegisterHRP).
+func WithRegisteredHRP(chainID *big.Int, hrp string) Option {
+	return func(e *EvmosSECP256K1) {
+		e.RegisterHRP(chainID, hrp)
+	}
+}
+
+// WithDisplayEIP712Hashes enables logging the EIP-712 hashes before signing (see
+// SetDisplayEIP712Hashes).
+func WithDisplayEIP712Hashes(display bool) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetDisplayEIP712Hashes(display)
+	}
+}
+
+// WithEIP712FullDisplayVersion overrides the minimum version of the Ethereum app
+// displaying the fields of EIP-712 messages (see SetEIP712FullDisplayVersion).
+func WithEIP712FullDisplayVersion(major, minor, patch uint8) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetEIP712FullDisplayVersion(major, minor, patch)
+	}
+}
+
+// WithDisplayMode sets how EIP-712 messages are presented on the Ledger (see
+// SetDisplayMode).
+func WithDisplayMode(mode DisplayMode) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetDisplayMode(mode)
+	}
+}
+
+// WithForceConfirmation requires an explicit confirmation of the signing address on
+// the device for every signature (see SetForceConfirmation).
+func WithForceConfirmation(force bool) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetForceConfirmation(force)
+	}
+}
+
+// WithSignatureVerification checks every signature against the account derived
+// again from the device (see SetSignatureVerification).
+func WithSignatureVerification(verify bool) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetSignatureVerification(verify)
+	}
+}
+
+// WithCoinType sets the SLIP-44 coin type expected in HD paths (see SetCoinType).
+func WithCoinType(coinType uint32) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetCoinType(coinType)
+	}
+}
+
+// WithAllowedCoinTypes accepts HD paths using any of the given coin types, in
+// addition to the expected one (see SetAllowedCoinTypes).
+func WithAllowedCoinTypes(coinTypes ...uint32) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetAllowedCoinTypes(coinTypes...)
+	}
+}
+
+// WithTypedDataBuilder overrides the conversion of sign docs into EIP-712 typed
+// data (see SetTypedDataBuilder).
+func WithTypedDataBuilder(builder TypedDataBuilder) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetTypedDataBuilder(builder)
+	}
+}
+
+// WithTokenInfo sets the ERC-20 token descriptors provided to the Ledger before each
+// EIP-712 signing request (see SetTokenInfo).
+func WithTokenInfo(tokens ...accounts.TokenInfo) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetTokenInfo(tokens...)
+	}
+}
+
+// WithExpectedChainID makes every EIP-712 signing request check the chain ID of the
+// typed data domain (see SetExpectedChainID).
+func WithExpectedChainID(chainID *big.Int) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetExpectedChainID(chainID)
+	}
+}
+
+// WithExpectedDomain makes every EIP-712 signing request check the fields of the
+// typed data domain (see SetExpectedDomain).
+func WithExpectedDomain(name, version string, chainID *big.Int, verifyingContract *common.Address, salt []byte) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetExpectedDomain(name, version, chainID, verifyingContract, salt)
+	}
+}
+
+// WithMetrics sets the Metrics receiving the measurements of the signing and
+// derivation requests (see SetMetrics).
+func WithMetrics(metrics Metrics) Option {
+	return func(e *EvmosSECP256K1) {
+		e.SetMetrics(metrics)
+	}
+}
@@ -0,0 +1,198 @@
+package ledger_test
+
+import (
+	"errors"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos-ledger-go/usbwallet"
+)
+
+func (suite *LedgerTestSuite) TestEvmosLedgerDerivationWithOptions() {
+	logger := &recordingLogger{}
+
+	derivationFunc := ledger.EvmosLedgerDerivationWithOptions(
+		ledger.WithLogger(logger),
+		ledger.WithWalletIndex(1),
+		ledger.WithConnectTimeout(0),
+	)
+	_, err := derivationFunc()
+	suite.Require().Error(err)
+
+	// The options are threaded into the instance used to connect
+	suite.Require().Equal([]string{"Detected 0 hardware wallet(s)"}, logger.debugs)
+}
+
+func (suite *LedgerTestSuite) TestOptions() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	logger := &recordingLogger{}
+	for _, opt := range []ledger.Option{
+		ledger.WithLogger(logger),
+		ledger.WithDisplayEIP712Hashes(true),
+		ledger.WithCacheEnabled(true),
+		ledger.WithAllowedHRPs("evmos"),
+		ledger.WithRetry(1, 0),
+	} {
+		opt(suite.ledger)
+	}
+
+	RegisterOpen(suite.mockWallet)
+	RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+	RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+
+	_, err = suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+	suite.Require().NoError(err)
+	suite.Require().Len(logger.infos, 4)
+
+	_, _, err = suite.ledger.GetAddressPubKeySECP256K1(gethaccounts.DefaultBaseDerivationPath, "cosmos")
+	suite.Require().ErrorIs(err, ledger.ErrInvalidHRP)
+
+	for i := 0; i < 2; i++ {
+		_, _, err = suite.ledger.GetAddressPubKeySECP256K1(gethaccounts.DefaultBaseDerivationPath, "evmos")
+		suite.Require().NoError(err)
+	}
+
+	// Signing always queries the device, while the second address request is served from the cache
+	suite.mockWallet.AssertNumberOfCalls(suite.T(), "Derive", 2)
+}
+
+func (suite *LedgerTestSuite) TestPassphraseProvider() {
+	errProvider := errors.New("passphrase prompt cancelled")
+
+	testCases := []struct {
+		name     string
+		provider func() (string, error)
+		mockFunc func()
+		expCalls int
+		expErr   error
+	}{
+		{
+			"pass - passphrase supplied when opening a closed wallet",
+			func() (string, error) { return "hidden", nil },
+			func() {
+				RegisterStatus(suite.mockWallet, usbwallet.StatusClosed)
+				suite.mockWallet.On("Open", "hidden").Return(nil)
+			},
+			1,
+			nil,
+		},
+		{
+			"pass - provider not invoked for an open wallet",
+			func() (string, error) { return "hidden", nil },
+			func() {
+				RegisterStatus(suite.mockWallet, "Ethereum app v1.10.2 online")
+				RegisterOpenError(suite.mockWallet, gethaccounts.ErrWalletAlreadyOpen)
+			},
+			0,
+			nil,
+		},
+		{
+			"fail - provider error",
+			func() (string, error) { return "", errProvider },
+			func() {
+				RegisterStatus(suite.mockWallet, usbwallet.StatusClosed)
+			},
+			1,
+			errProvider,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			calls := 0
+			ledger.WithPassphraseProvider(func() (string, error) {
+				calls++
+				return tc.provider()
+			})(suite.ledger)
+			tc.mockFunc()
+
+			err := suite.ledger.Open()
+			suite.Require().Equal(tc.expCalls, calls)
+			if tc.expErr != nil {
+				suite.Require().ErrorIs(err, tc.expErr)
+				return
+			}
+
+			suite.Require().NoError(err)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestAutoReopen() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	testCases := []struct {
+		name       string
+		autoReopen bool
+		mockFunc   func()
+		expOpens   int
+		expErr     error
+	}{
+		{
+			"pass - wallet reopened by default",
+			true,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			1,
+			nil,
+		},
+		{
+			"pass - open wallet used as is",
+			false,
+			func() {
+				RegisterStatus(suite.mockWallet, "Ethereum app v1.10.2 online")
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			0,
+			nil,
+		},
+		{
+			"fail - closed wallet not reopened",
+			false,
+			func() {
+				RegisterStatus(suite.mockWallet, usbwallet.StatusClosed)
+			},
+			0,
+			ledger.ErrWalletClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			ledger.WithAutoReopen(tc.autoReopen)(suite.ledger)
+			tc.mockFunc()
+
+			_, err := suite.ledger.GetPublicKeySECP256K1(gethaccounts.DefaultBaseDerivationPath)
+			suite.mockWallet.AssertNumberOfCalls(suite.T(), "Open", tc.expOpens)
+			if tc.expErr != nil {
+				suite.Require().ErrorIs(err, tc.expErr)
+				return
+			}
+
+			suite.Require().NoError(err)
+		})
+	}
+
+	// The wallet can still be opened explicitly
+	suite.SetupTest() // reset
+	suite.ledger.SetAutoReopen(false)
+	RegisterOpen(suite.mockWallet)
+	suite.Require().NoError(suite.ledger.Open())
+	suite.mockWallet.AssertNumberOfCalls(suite.T(), "Open", 1)
+}
@@ -0,0 +1,65 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+)
+
+// SignPersonalMessage signs an arbitrary message following the EIP-191 personal_sign
+// scheme, i.e. the device signs keccak256("\x19Ethereum Signed Message:\n" + len(message) + message).
+// This is the signing method used by dApp login flows and off-chain messages that
+// don't rely on typed data. The signature is returned in the 65-byte [R || S || V]
+// format, where V is 27 or 28 (see ParseSignature).
+func (e *EvmosSECP256K1) SignPersonalMessage(hdPath []uint32, message []byte) ([]byte, error) {
+	e.promptSigning()
+
+	return e.signWithContext(context.Background(), func(ctx context.Context) ([]byte, error) {
+		return e.signPersonalMessage(ctx, hdPath, message)
+	})
+}
+
+// signPersonalMessage signs the message with the account derived from hdPath.
+//
+// Note, signPersonalMessage assumes the lock is held!
+func (e *EvmosSECP256K1) signPersonalMessage(ctx context.Context, hdPath []uint32, message []byte) ([]byte, error) {
+	if e.PrimaryWallet == nil {
+		return nil, errors.New("unable to sign with Ledger: no wallet found")
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return nil, err
+	}
+
+	account, err := e.deriveSigner(ctx, hdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	e.reportStage(StageAwaitConfirmation)
+
+	var signature []byte
+	err = e.observeSign(func() error {
+		return e.retry(ctx, func() (err error) {
+			signature, err = e.PrimaryWallet.SignText(account, message)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error generating signature, please retry: %w", pathError("sign", hdPath, err))
+	}
+
+	signature, err = NormalizeSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.verifySignature(ctx, hdPath, gethaccounts.TextHash(message), signature); err != nil {
+		return nil, err
+	}
+
+	return signature, nil
+}
@@ -0,0 +1,88 @@
+package ledger_test
+
+import (
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestSignPersonalMessage() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	message := []byte("Sign in to Evmos")
+	hash := gethaccounts.TextHash(message)
+	expSignature, err := crypto.Sign(hash, privKey)
+	suite.Require().NoError(err)
+	expSignature[crypto.RecoveryIDOffset] += 27
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expErr   error
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			nil,
+			false,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			nil,
+			false,
+		},
+		{
+			"fail - user rejected the message",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTextError(suite.mockWallet, account, message)
+			},
+			ledger.ErrUserRejected,
+			false,
+		},
+		{
+			"pass - message signed",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignText(suite.mockWallet, account, message, expSignature)
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			signature, err := suite.ledger.SignPersonalMessage(gethaccounts.DefaultBaseDerivationPath, message)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(expSignature, signature)
+				suite.Require().Len(signature, crypto.SignatureLength)
+			} else {
+				suite.Require().Error(err)
+				if tc.expErr != nil {
+					suite.Require().ErrorIs(err, tc.expErr)
+				}
+			}
+		})
+	}
+}
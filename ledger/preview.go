@@ -0,0 +1,65 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+)
+
+// PreviewInfo describes what SignSECP256K1 would send to the Ledger for a sign doc.
+type PreviewInfo struct {
+	Account     accounts.Account   `json:"account"`     // Signing account, empty if no wallet is connected
+	TypedData   apitypes.TypedData `json:"typedData"`   // EIP-712 typed data built from the sign doc
+	DomainHash  []byte             `json:"domainHash"`  // Domain separator displayed by the Ledger
+	MessageHash []byte             `json:"messageHash"` // Message hash displayed by the Ledger
+}
+
+// PreviewSign performs every step of SignSECP256K1 up to, but excluding, the
+// signing request sent to the device: it builds the EIP-712 typed data from the
+// sign doc, checks its chain ID (see SetExpectedChainID) and computes the hashes
+// the Ledger will display. If a wallet is connected, the signing account is
+// derived as well, without showing its address on the device. This allows
+// integrators to verify what will be signed before prompting the user.
+func (e *EvmosSECP256K1) PreviewSign(hdPath []uint32, signDocBytes []byte) (PreviewInfo, error) {
+	typedData, err := e.buildTypedData(signDocBytes)
+	if err != nil {
+		return PreviewInfo{}, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.verifyChainID(typedData); err != nil {
+		return PreviewInfo{}, err
+	}
+
+	domainHash, messageHash, err := ComputeEIP712Hashes(typedData)
+	if err != nil {
+		return PreviewInfo{}, fmt.Errorf("unable to generate EIP-712 hash for object: %w", err)
+	}
+
+	info := PreviewInfo{
+		TypedData:   typedData,
+		DomainHash:  domainHash,
+		MessageHash: messageHash,
+	}
+
+	if e.PrimaryWallet == nil {
+		return info, nil
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return PreviewInfo{}, err
+	}
+
+	info.Account, err = e.cachedDerive(context.Background(), hdPath, false)
+	if err != nil {
+		return PreviewInfo{}, fmt.Errorf("unable to derive Ledger address, please open the Ethereum app and retry: %w", err)
+	}
+
+	return info, nil
+}
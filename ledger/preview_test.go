@@ -0,0 +1,91 @@
+package ledger_test
+
+import (
+	"math/big"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos/v14/ethereum/eip712"
+)
+
+func (suite *LedgerTestSuite) TestPreviewSign() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	typedData, err := eip712.GetEIP712TypedDataForMsg(suite.txAmino)
+	suite.Require().NoError(err)
+	domainHash, messageHash, err := ledger.ComputeEIP712Hashes(typedData)
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name       string
+		mockFunc   func()
+		expPass    bool
+		expAccount bool
+	}{
+		{
+			"pass - no wallet connected",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			true,
+			false,
+		},
+		{
+			"fail - chain ID mismatch",
+			func() {
+				suite.ledger.SetExpectedChainID(big.NewInt(9001))
+			},
+			false,
+			false,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			false,
+			false,
+		},
+		{
+			"pass - signing account derived",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			true,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+
+			info, err := suite.ledger.PreviewSign(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(typedData, info.TypedData)
+			suite.Require().Equal(domainHash, info.DomainHash)
+			suite.Require().Equal(messageHash, info.MessageHash)
+			if tc.expAccount {
+				suite.Require().Equal(addr, info.Account.Address)
+			} else {
+				suite.Require().Nil(info.Account.PublicKey)
+			}
+
+			// Nothing is sent to the device for signing, nor displayed
+			suite.mockWallet.AssertNotCalled(suite.T(), "SignTypedData")
+			suite.mockWallet.AssertNotCalled(suite.T(), "DeriveWithDisplay")
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package ledger
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PublicKey is a secp256k1 public key derived by the Ledger, which can be encoded in
+// the form expected by each consumer, e.g. the 33-byte compressed form of the
+// Cosmos SDK keyring or the 65-byte uncompressed form of go-ethereum, so that
+// callers don't need to convert the raw bytes themselves.
+type PublicKey struct {
+	x [32]byte // X coordinate of the point, big endian
+	y [32]byte // Y coordinate of the point, big endian
+}
+
+// ParsePublicKey parses a 33-byte compressed or 65-byte uncompressed SEC1 encoded
+// secp256k1 public key, such as the ones returned by GetPublicKeySECP256K1 and
+// GetCompressedPublicKeySECP256K1.
+func ParsePublicKey(pubKeyBytes []byte) (PublicKey, error) {
+	switch len(pubKeyBytes) {
+	case 33:
+		pubKey, err := crypto.DecompressPubkey(pubKeyBytes)
+		if err != nil {
+			return PublicKey{}, fmt.Errorf("invalid compressed public key: %w", err)
+		}
+		return newPublicKey(pubKey), nil
+	case 65:
+		pubKey, err := crypto.UnmarshalPubkey(pubKeyBytes)
+		if err != nil {
+			return PublicKey{}, fmt.Errorf("invalid uncompressed public key: %w", err)
+		}
+		return newPublicKey(pubKey), nil
+	default:
+		return PublicKey{}, fmt.Errorf("invalid public key length: %d", len(pubKeyBytes))
+	}
+}
+
+// newPublicKey returns the coordinates of the ECDSA public key.
+func newPublicKey(pubKey *ecdsa.PublicKey) PublicKey {
+	var p PublicKey
+	pubKey.X.FillBytes(p.x[:])
+	pubKey.Y.FillBytes(p.y[:])
+	return p
+}
+
+// Compressed returns the 33-byte SEC1 compressed form of the public key, prefixed
+// with 0x02 or 0x03 depending on the parity of Y, as used by the Cosmos SDK keys.
+func (p PublicKey) Compressed() []byte {
+	compressed := make([]byte, 33)
+	compressed[0] = 0x02 | p.y[31]&1
+	copy(compressed[1:], p.x[:])
+	return compressed
+}
+
+// Uncompressed returns the 65-byte SEC1 uncompressed form of the public key, i.e.
+// 0x04 || X || Y, as returned by GetPublicKeySECP256K1.
+func (p PublicKey) Uncompressed() []byte {
+	uncompressed := make([]byte, 65)
+	uncompressed[0] = 0x04
+	copy(uncompressed[1:], p.x[:])
+	copy(uncompressed[33:], p.y[:])
+	return uncompressed
+}
+
+// XY returns the big endian coordinates of the public key.
+func (p PublicKey) XY() (x, y [32]byte) {
+	return p.x, p.y
+}
+
+// GetPublicKey behaves like GetPublicKeySECP256K1, but returns the public key as a
+// PublicKey, which can be encoded in the compressed, uncompressed or raw X/Y forms.
+func (e *EvmosSECP256K1) GetPublicKey(hdPath []uint32) (PublicKey, error) {
+	account, err := e.sharedDerive(hdPath)
+	if err != nil {
+		return PublicKey{}, err
+	}
+
+	return newPublicKey(account.PublicKey), nil
+}
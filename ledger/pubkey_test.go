@@ -0,0 +1,99 @@
+package ledger_test
+
+import (
+	"bytes"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestParsePublicKey() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name    string
+		input   []byte
+		expPass bool
+	}{
+		{"pass - uncompressed public key", crypto.FromECDSAPub(&privKey.PublicKey), true},
+		{"pass - compressed public key", crypto.CompressPubkey(&privKey.PublicKey), true},
+		{"fail - empty input", nil, false},
+		{"fail - invalid length", bytes.Repeat([]byte{1}, 32), false},
+		{"fail - invalid compressed public key", append([]byte{0x05}, bytes.Repeat([]byte{1}, 32)...), false},
+		{"fail - invalid uncompressed public key", append([]byte{0x04}, bytes.Repeat([]byte{0}, 64)...), false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			pubKey, err := ledger.ParsePublicKey(tc.input)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(crypto.CompressPubkey(&privKey.PublicKey), pubKey.Compressed())
+			suite.Require().Equal(crypto.FromECDSAPub(&privKey.PublicKey), pubKey.Uncompressed())
+
+			x, y := pubKey.XY()
+			suite.Require().Equal(privKey.PublicKey.X.FillBytes(make([]byte, 32)), x[:])
+			suite.Require().Equal(privKey.PublicKey.Y.FillBytes(make([]byte, 32)), y[:])
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestGetPublicKey() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			false,
+		},
+		{
+			"pass - public key derived",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+
+			pubKey, err := suite.ledger.GetPublicKey(gethaccounts.DefaultBaseDerivationPath)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(crypto.FromECDSAPub(&privKey.PublicKey), pubKey.Uncompressed())
+			suite.Require().Equal(crypto.CompressPubkey(&privKey.PublicKey), pubKey.Compressed())
+		})
+	}
+}
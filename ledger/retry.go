@@ -0,0 +1,154 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/usbwallet"
+)
+
+// SetRetry configures how device requests are retried upon transient USB
+// communication failures, or while the device is held by another application such
+// as Ledger Live. A request is attempted at most attempts times, waiting delay
+// before the first retry and doubling the delay after every further one. Errors
+// returned by the device itself, such as a declined signature, are never retried
+// so the user is not prompted again. By default, requests are not retried.
+func (e *EvmosSECP256K1) SetRetry(attempts int, delay time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.retryAttempts = attempts
+	e.retryDelay = delay
+}
+
+// openWallet opens the wallet, retrying while the device is busy or unreachable,
+// until the context is done.
+func (e *EvmosSECP256K1) openWallet(ctx context.Context, wallet accounts.Wallet) error {
+	passphrase, err := e.passphrase(wallet)
+	if err != nil {
+		return err
+	}
+
+	return e.retry(ctx, func() error {
+		return wallet.Open(passphrase)
+	})
+}
+
+// passphrase returns the passphrase to open the wallet with. The passphrase
+// provider is only invoked if the wallet is closed, since opening an open wallet
+// fails regardless of the passphrase.
+func (e *EvmosSECP256K1) passphrase(wallet accounts.Wallet) (string, error) {
+	if e.passphraseFn == nil {
+		return "", nil
+	}
+
+	if status, _ := wallet.Status(); status != usbwallet.StatusClosed {
+		return "", nil
+	}
+
+	passphrase, err := e.passphraseFn()
+	if err != nil {
+		return "", fmt.Errorf("could not get wallet passphrase: %w", err)
+	}
+
+	return passphrase, nil
+}
+
+// retry calls fn until it succeeds, returns a non-transient error, the configured
+// number of attempts is exhausted or the context is done.
+func (e *EvmosSECP256K1) retry(ctx context.Context, fn func() error) error {
+	delay := e.retryDelay
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= e.retryAttempts || !isTransientError(err) {
+			return err
+		}
+
+		e.log().Debugf("Transient Ledger communication error, retrying in %s (attempt %d/%d): %v",
+			delay, attempt+1, e.retryAttempts, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+// derive derives the account at the given HD path, retrying upon transient failures.
+// If display is set, the address is shown on the device for the user to confirm.
+// The HD path is validated before being sent to the device.
+func (e *EvmosSECP256K1) derive(ctx context.Context, hdPath []uint32, display bool) (accounts.Account, error) {
+	if err := e.validateHDPath(hdPath); err != nil {
+		return accounts.Account{}, err
+	}
+
+	var account accounts.Account
+
+	err := e.observeDerive(func() error {
+		return e.retry(ctx, func() (err error) {
+			if display {
+				account, err = e.PrimaryWallet.DeriveWithDisplay(hdPath, true)
+			} else {
+				account, err = e.PrimaryWallet.Derive(hdPath, true)
+			}
+			return err
+		})
+	})
+	if err != nil {
+		return accounts.Account{}, pathError("derive", hdPath, err)
+	}
+
+	// Some app versions reply without a public key, which would panic once encoded
+	if account.PublicKey == nil {
+		return accounts.Account{}, pathError("derive", hdPath, ErrMissingPublicKey)
+	}
+
+	return account, nil
+}
+
+// pathError wraps the error of a device request with the name of the operation and
+// the HD path it was requested for (e.g. "derive m/44'/60'/0'/0/7: ..."), so that
+// failures can be told apart when iterating over multiple accounts.
+func pathError(op string, hdPath []uint32, err error) error {
+	return fmt.Errorf("%s %s: %w", op, gethaccounts.DerivationPath(hdPath), err)
+}
+
+// cachedDerive returns the cached account derived at the given HD path, only
+// deriving it if it wasn't cached yet. If display is set, the address is always
+// shown on the device for the user to confirm.
+//
+// Note, cachedDerive assumes the lock is held!
+func (e *EvmosSECP256K1) cachedDerive(ctx context.Context, hdPath []uint32, display bool) (accounts.Account, error) {
+	if account, ok := e.cachedAccount(hdPath); ok && !display {
+		return account, nil
+	}
+
+	account, err := e.derive(ctx, hdPath, display)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+
+	e.cacheAccount(hdPath, account)
+
+	return account, nil
+}
+
+// isTransientError returns whether the error was caused by a failed USB transfer or
+// by the device being held by another application, in which case the request can
+// safely be retried. Timed out commands are not retried, since the connection to
+// the device is closed.
+func isTransientError(err error) bool {
+	if errors.Is(err, usbwallet.ErrCommandTimeout) {
+		return false
+	}
+	return errors.Is(err, usbwallet.ErrDeviceIO) || errors.Is(err, usbwallet.ErrDeviceBusy)
+}
@@ -0,0 +1,156 @@
+package ledger_test
+
+import (
+	"time"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos-ledger-go/usbwallet"
+)
+
+func (suite *LedgerTestSuite) TestRetry() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	expPubkeyBz := crypto.FromECDSAPub(&privKey.PublicKey)
+
+	testCases := []struct {
+		name           string
+		attempts       int
+		mockFunc       func()
+		expDeriveCalls int
+		expPass        bool
+	}{
+		{
+			"fail - transient error without retries",
+			0,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveTransientError(suite.mockWallet)
+			},
+			1,
+			false,
+		},
+		{
+			"fail - non-transient errors are not retried",
+			3,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			1,
+			false,
+		},
+		{
+			"fail - attempts exhausted",
+			2,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveTransientError(suite.mockWallet)
+				RegisterDeriveTransientError(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			2,
+			false,
+		},
+		{
+			"pass - transient error recovered",
+			3,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveTransientError(suite.mockWallet)
+				RegisterDeriveTransientError(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			3,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			suite.ledger.SetRetry(tc.attempts, time.Millisecond)
+			pubKeyBz, err := suite.ledger.GetPublicKeySECP256K1(gethaccounts.DefaultBaseDerivationPath)
+			suite.mockWallet.AssertNumberOfCalls(suite.T(), "Derive", tc.expDeriveCalls)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(expPubkeyBz, pubKeyBz)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestRetryDeviceBusy() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	testCases := []struct {
+		name         string
+		attempts     int
+		mockFunc     func()
+		expOpenCalls int
+		expPass      bool
+	}{
+		{
+			"fail - device busy without retries",
+			0,
+			func() {
+				RegisterOpenBusy(suite.mockWallet)
+			},
+			1,
+			false,
+		},
+		{
+			"pass - device released before the attempts are exhausted",
+			3,
+			func() {
+				RegisterOpenBusy(suite.mockWallet)
+				RegisterOpenBusy(suite.mockWallet)
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			},
+			3,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			suite.ledger.SetRetry(tc.attempts, time.Millisecond)
+			_, err := suite.ledger.GetPublicKeySECP256K1(gethaccounts.DefaultBaseDerivationPath)
+			suite.mockWallet.AssertNumberOfCalls(suite.T(), "Open", tc.expOpenCalls)
+			if tc.expPass {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().ErrorIs(err, ledger.ErrDeviceBusy)
+				suite.Require().ErrorContains(err, "please close Ledger Live")
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestRetryDeviceOpenError() {
+	// The transport cannot reach the device, which is not a busy device
+	transport := &bridgeTransport{
+		devices: []usbwallet.DeviceInfo{{Path: "tcp://127.0.0.1:9999", ProductID: 0x4015}},
+	}
+
+	derivationFunc := ledger.EvmosLedgerDerivationWithOptions(
+		ledger.WithTransport(transport),
+		ledger.WithRetry(3, time.Millisecond),
+	)
+	_, err := derivationFunc()
+	suite.Require().ErrorIs(err, ledger.ErrDeviceOpen)
+	suite.Require().ErrorIs(err, errBridgeUnreachable)
+	suite.Require().NotErrorIs(err, ledger.ErrDeviceBusy)
+	suite.Require().Equal([]string{"tcp://127.0.0.1:9999"}, transport.opened)
+}
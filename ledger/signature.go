@@ -0,0 +1,166 @@
+package ledger
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ethereumRecoveryOffset is added to the recovery ID (0 or 1) to produce the V value
+// of Ethereum signatures.
+const ethereumRecoveryOffset = 27
+
+var (
+	// secp256k1N is the order of the secp256k1 curve.
+	secp256k1N = crypto.S256().Params().N
+
+	// secp256k1HalfN is the largest S value of low-S signatures.
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// SignSECP256K1Cosmos behaves like SignSECP256K1, but returns the signature in the
+// canonical 64-byte [R || S] format used by the Cosmos SDK secp256k1 keys.
+func (e *EvmosSECP256K1) SignSECP256K1Cosmos(hdPath []uint32, signDocBytes []byte) ([]byte, error) {
+	signature, err := e.SignSECP256K1(hdPath, signDocBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToCosmosSignature(signature)
+}
+
+// SignSECP256K1Ethereum behaves like SignSECP256K1, and returns the signature in the
+// 65-byte [R || S || V] format used by Ethereum, where V is 27 or 28.
+func (e *EvmosSECP256K1) SignSECP256K1Ethereum(hdPath []uint32, signDocBytes []byte) ([]byte, error) {
+	return e.SignSECP256K1(hdPath, signDocBytes)
+}
+
+// ToCosmosSignature converts a 64-byte [R || S] or 65-byte [R || S || V] signature
+// into the 64-byte [R || S] format, dropping the recovery ID.
+func ToCosmosSignature(signature []byte) ([]byte, error) {
+	switch len(signature) {
+	case crypto.SignatureLength - 1, crypto.SignatureLength:
+		cosmosSig := make([]byte, crypto.RecoveryIDOffset)
+		copy(cosmosSig, signature[:crypto.RecoveryIDOffset])
+		return cosmosSig, nil
+	default:
+		return nil, fmt.Errorf("invalid signature length: %d", len(signature))
+	}
+}
+
+// ToEthereumSignature converts a 65-byte [R || S || V] signature, where V is either
+// the raw recovery ID (0 or 1) or already offset by 27, into the Ethereum format
+// where V is 27 or 28. A 64-byte signature cannot be converted, since the recovery
+// ID can only be computed from the signed hash.
+func ToEthereumSignature(signature []byte) ([]byte, error) {
+	sig, err := ParseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return sig.Bytes65(), nil
+}
+
+// NormalizeSignature converts a 64-byte [R || S] or 65-byte [R || S || V] signature
+// into its low-S form, which is the only one accepted by Ethereum (EIP-2) and the
+// Cosmos SDK: an S value greater than half of the curve order is replaced by its
+// negation, and the recovery ID is flipped accordingly, so that the signature still
+// recovers the same public key. Low-S signatures are returned unchanged. As with
+// ToEthereumSignature, V is returned as 27 or 28.
+//
+// The signatures returned by SignSECP256K1, SignTypedData and SignPersonalMessage
+// are already normalized.
+func NormalizeSignature(signature []byte) ([]byte, error) {
+	switch len(signature) {
+	case crypto.SignatureLength - 1:
+		var sig Signature
+		copy(sig.R[:], signature[:32])
+		copy(sig.S[:], signature[32:])
+		return sig.Normalize().Bytes64(), nil
+	case crypto.SignatureLength:
+		sig, err := ParseSignature(signature)
+		if err != nil {
+			return nil, err
+		}
+		return sig.Normalize().Bytes65(), nil
+	default:
+		return nil, fmt.Errorf("invalid signature length: %d", len(signature))
+	}
+}
+
+// Signature is a secp256k1 signature returned by the Ledger, split into its
+// components so that callers don't need to slice the raw bytes.
+type Signature struct {
+	R [32]byte // R value of the signature, big endian
+	S [32]byte // S value of the signature, big endian
+	V byte     // Recovery ID offset by 27, i.e. 27 or 28
+}
+
+// ParseSignature parses a 65-byte [R || S || V] signature, such as the ones returned
+// by SignSECP256K1 and SignPersonalMessage, where V is either the raw recovery ID
+// (0 or 1) or already offset by 27. A 64-byte signature cannot be parsed, since the
+// recovery ID can only be computed from the signed hash.
+func ParseSignature(signature []byte) (Signature, error) {
+	if len(signature) != crypto.SignatureLength {
+		return Signature{}, fmt.Errorf("invalid signature length: %d", len(signature))
+	}
+
+	var sig Signature
+	copy(sig.R[:], signature[:32])
+	copy(sig.S[:], signature[32:crypto.RecoveryIDOffset])
+
+	switch v := signature[crypto.RecoveryIDOffset]; v {
+	case 0, 1:
+		sig.V = v + ethereumRecoveryOffset
+	case ethereumRecoveryOffset, ethereumRecoveryOffset + 1:
+		sig.V = v
+	default:
+		return Signature{}, fmt.Errorf("invalid signature recovery value: %d", v)
+	}
+
+	return sig, nil
+}
+
+// IsLowS reports whether S is at most half of the curve order (see NormalizeSignature).
+func (s Signature) IsLowS() bool {
+	return new(big.Int).SetBytes(s.S[:]).Cmp(secp256k1HalfN) <= 0
+}
+
+// Normalize returns the low-S form of the signature, negating S and flipping the
+// recovery ID if S is greater than half of the curve order (see NormalizeSignature).
+func (s Signature) Normalize() Signature {
+	if s.IsLowS() {
+		return s
+	}
+
+	sValue := new(big.Int).SetBytes(s.S[:])
+	sValue.Sub(secp256k1N, sValue).FillBytes(s.S[:])
+
+	// Flip 27 and 28, leaving an unset V as is for 64-byte signatures
+	if s.V != 0 {
+		s.V = 2*ethereumRecoveryOffset + 1 - s.V
+	}
+
+	return s
+}
+
+// RecoveryID returns the raw recovery ID of the signature (0 or 1), as expected by
+// crypto.Ecrecover.
+func (s Signature) RecoveryID() byte {
+	return s.V - ethereumRecoveryOffset
+}
+
+// Bytes64 returns the signature in the 64-byte [R || S] format used by the Cosmos
+// SDK secp256k1 keys.
+func (s Signature) Bytes64() []byte {
+	bz := make([]byte, 0, crypto.RecoveryIDOffset)
+	bz = append(bz, s.R[:]...)
+	return append(bz, s.S[:]...)
+}
+
+// Bytes65 returns the signature in the 65-byte Ethereum [R || S || V] format, where
+// V is 27 or 28.
+func (s Signature) Bytes65() []byte {
+	return append(s.Bytes64(), s.V)
+}
@@ -0,0 +1,244 @@
+package ledger_test
+
+import (
+	"math/big"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestSignatureFormats() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	testCases := []struct {
+		name    string
+		deviceV byte
+		expEthV byte
+		expPass bool
+	}{
+		{
+			"pass - V returned as 27/28",
+			28,
+			28,
+			true,
+		},
+		{
+			"pass - V returned as 0/1",
+			1,
+			28,
+			true,
+		},
+		{
+			"fail - invalid V",
+			5,
+			0,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			RegisterOpen(suite.mockWallet)
+			RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			RegisterSignTypedDataWithSignature(suite.mockWallet, account, suite.txAmino, mockSignature(tc.deviceV))
+
+			ethSig, err := suite.ledger.SignSECP256K1Ethereum(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+			cosmosSig, cosmosErr := suite.ledger.SignSECP256K1Cosmos(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				suite.Require().Error(cosmosErr)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Len(ethSig, crypto.SignatureLength)
+			suite.Require().Equal(tc.expEthV, ethSig[crypto.RecoveryIDOffset])
+
+			suite.Require().NoError(cosmosErr)
+			suite.Require().Equal(ethSig[:crypto.RecoveryIDOffset], cosmosSig)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestSignatureConversion() {
+	sig := make([]byte, crypto.SignatureLength)
+	for i := range sig {
+		sig[i] = byte(i)
+	}
+
+	testCases := []struct {
+		name           string
+		signature      []byte
+		v              byte
+		expCosmosPass  bool
+		expEthereumV   byte
+		expEthereumErr bool
+	}{
+		{"pass - 65 bytes with V as 27", sig, 27, true, 27, false},
+		{"pass - 65 bytes with V as 0", sig, 0, true, 27, false},
+		{"pass - 65 bytes with V as 1", sig, 1, true, 28, false},
+		{"fail - 65 bytes with invalid V", sig, 30, true, 0, true},
+		{"fail - 64 bytes cannot be converted to Ethereum", sig[:crypto.RecoveryIDOffset], 0, true, 0, true},
+		{"fail - invalid length", sig[:10], 0, false, 0, true},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			signature := make([]byte, len(tc.signature))
+			copy(signature, tc.signature)
+			if len(signature) == crypto.SignatureLength {
+				signature[crypto.RecoveryIDOffset] = tc.v
+			}
+
+			cosmosSig, err := ledger.ToCosmosSignature(signature)
+			if tc.expCosmosPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(signature[:crypto.RecoveryIDOffset], cosmosSig)
+			} else {
+				suite.Require().Error(err)
+			}
+
+			ethSig, err := ledger.ToEthereumSignature(signature)
+			if tc.expEthereumErr {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(signature[:crypto.RecoveryIDOffset], ethSig[:crypto.RecoveryIDOffset])
+			suite.Require().Equal(tc.expEthereumV, ethSig[crypto.RecoveryIDOffset])
+			// The input must not be modified
+			suite.Require().Equal(tc.v, signature[crypto.RecoveryIDOffset])
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestParseSignature() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	hash := crypto.Keccak256([]byte("message"))
+	rawSig, err := crypto.Sign(hash, privKey)
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name    string
+		v       byte
+		length  int
+		expPass bool
+	}{
+		{"pass - V as recovery ID", rawSig[crypto.RecoveryIDOffset], crypto.SignatureLength, true},
+		{"pass - V offset by 27", rawSig[crypto.RecoveryIDOffset] + 27, crypto.SignatureLength, true},
+		{"fail - invalid V", 30, crypto.SignatureLength, false},
+		{"fail - 64 bytes", 0, crypto.RecoveryIDOffset, false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			signature := make([]byte, crypto.SignatureLength)
+			copy(signature, rawSig)
+			signature[crypto.RecoveryIDOffset] = tc.v
+
+			sig, err := ledger.ParseSignature(signature[:tc.length])
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(rawSig[:32], sig.R[:])
+			suite.Require().Equal(rawSig[32:crypto.RecoveryIDOffset], sig.S[:])
+			suite.Require().Equal(rawSig[crypto.RecoveryIDOffset]+27, sig.V)
+			suite.Require().Equal(rawSig[:crypto.RecoveryIDOffset], sig.Bytes64())
+			suite.Require().Len(sig.Bytes65(), crypto.SignatureLength)
+			suite.Require().Equal(sig.V, sig.Bytes65()[crypto.RecoveryIDOffset])
+
+			recovered, err := crypto.SigToPub(hash, append(sig.Bytes64(), sig.RecoveryID()))
+			suite.Require().NoError(err)
+			suite.Require().Equal(privKey.PublicKey, *recovered)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestNormalizeSignature() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	hash := crypto.Keccak256([]byte("normalize"))
+
+	// crypto.Sign always returns low-S signatures with V set to 0 or 1
+	lowS, err := crypto.Sign(hash, privKey)
+	suite.Require().NoError(err)
+	lowS[crypto.RecoveryIDOffset] += 27
+
+	// Negate S and flip V to get the equivalent high-S signature
+	highS := append([]byte(nil), lowS...)
+	s := new(big.Int).SetBytes(highS[32:64])
+	s.Sub(crypto.S256().Params().N, s).FillBytes(highS[32:64])
+	highS[crypto.RecoveryIDOffset] = 55 - highS[crypto.RecoveryIDOffset]
+
+	testCases := []struct {
+		name      string
+		signature []byte
+		expSig    []byte
+		expPass   bool
+	}{
+		{"pass - low-S signature unchanged", lowS, lowS, true},
+		{"pass - high-S signature normalized", highS, lowS, true},
+		{"pass - 64-byte high-S signature normalized", highS[:64], lowS[:64], true},
+		{"fail - invalid length", lowS[:32], nil, false},
+		{"fail - invalid V", append(append([]byte(nil), highS[:64]...), 5), nil, false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			normalized, err := ledger.NormalizeSignature(tc.signature)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expSig, normalized)
+		})
+	}
+
+	parsed, err := ledger.ParseSignature(highS)
+	suite.Require().NoError(err)
+	suite.Require().False(parsed.IsLowS())
+	suite.Require().True(parsed.Normalize().IsLowS())
+}
+
+func (suite *LedgerTestSuite) TestSignatureLowS() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	// S = N - 1 is the largest high-S value
+	highS := mockSignature(27)
+	new(big.Int).Sub(crypto.S256().Params().N, big.NewInt(1)).FillBytes(highS[32:64])
+
+	RegisterOpen(suite.mockWallet)
+	RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+	RegisterSignTypedDataWithSignature(suite.mockWallet, account, suite.txAmino, highS)
+
+	signature, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+	suite.Require().NoError(err)
+
+	sig, err := ledger.ParseSignature(signature)
+	suite.Require().NoError(err)
+	suite.Require().True(sig.IsLowS())
+	suite.Require().Equal(big.NewInt(1).FillBytes(make([]byte, 32)), sig.S[:])
+	suite.Require().Equal(byte(28), sig.V)
+}
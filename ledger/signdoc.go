@@ -0,0 +1,37 @@
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MaxSignDocSize is the maximum size in bytes of the sign docs accepted by
+// SignSECP256K1. Real sign docs are orders of magnitude smaller, so larger inputs
+// are rejected before being parsed.
+const MaxSignDocSize = 1 << 20
+
+// ErrInvalidSignDoc is returned when the sign doc passed to SignSECP256K1 is empty,
+// too large or malformed.
+var ErrInvalidSignDoc = errors.New("invalid sign doc")
+
+// validateSignDoc performs basic checks on the sign doc before it is parsed, so that
+// malformed or enormous inputs received over a network boundary fail early with a
+// clear error. Amino JSON sign docs must be valid JSON, while protobuf ones are
+// left to the typed data builder.
+func validateSignDoc(signDocBytes []byte) error {
+	if len(signDocBytes) == 0 {
+		return fmt.Errorf("%w: empty sign doc", ErrInvalidSignDoc)
+	}
+
+	if len(signDocBytes) > MaxSignDocSize {
+		return fmt.Errorf("%w: sign doc of %d bytes exceeds the maximum of %d bytes", ErrInvalidSignDoc, len(signDocBytes), MaxSignDocSize)
+	}
+
+	if trimmed := bytes.TrimSpace(signDocBytes); len(trimmed) > 0 && trimmed[0] == '{' && !json.Valid(trimmed) {
+		return fmt.Errorf("%w: malformed JSON sign doc", ErrInvalidSignDoc)
+	}
+
+	return nil
+}
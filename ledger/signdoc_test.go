@@ -0,0 +1,70 @@
+package ledger_test
+
+import (
+	"bytes"
+	"testing"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestSignDocValidation() {
+	testCases := []struct {
+		name       string
+		signDoc    []byte
+		builderErr bool
+	}{
+		{
+			"empty sign doc",
+			[]byte{},
+			false,
+		},
+		{
+			"sign doc too large",
+			bytes.Repeat([]byte{' '}, ledger.MaxSignDocSize+1),
+			false,
+		},
+		{
+			"malformed JSON sign doc",
+			[]byte(`{"account_number":"0",`),
+			false,
+		},
+		{
+			"builder panics",
+			[]byte("panicking sign doc"),
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			if tc.builderErr {
+				suite.ledger.SetTypedDataBuilder(func([]byte) (apitypes.TypedData, error) {
+					panic("index out of range")
+				})
+			}
+
+			_, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, tc.signDoc)
+			suite.Require().ErrorIs(err, ledger.ErrInvalidSignDoc)
+		})
+	}
+}
+
+func FuzzSignSECP256K1(f *testing.F) {
+	f.Add([]byte(`{"account_number":"0","chain_id":"evmos_9000-1","fee":{"amount":[],"gas":"0"},"memo":"","msgs":[],"sequence":"0"}`))
+	f.Add([]byte{0x0a, 0x02, 0x08, 0x01})
+	f.Add([]byte("{"))
+
+	f.Fuzz(func(t *testing.T, signDoc []byte) {
+		// Without a wallet, signing always fails, but parsing the sign doc must not panic
+		e := &ledger.EvmosSECP256K1{}
+		e.SetLogger(ledger.NopLogger{})
+
+		if _, err := e.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, signDoc); err == nil {
+			t.Fatal("expected an error without a wallet")
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SignResult describes a signature produced by the Ledger, along with the account
+// that produced it, e.g. for audit logging.
+type SignResult struct {
+	// Signature is the signature, in the same format as returned by SignSECP256K1.
+	Signature []byte
+	// Address is the Ethereum address of the account derived by the device.
+	Address common.Address
+	// HDPath is the HD path of the signing account.
+	HDPath []uint32
+	// DisplayedAsHash is set if the device displayed the domain and message hashes
+	// instead of the fields of the message, so the user only verified the hashes
+	// (see SetDisplayMode).
+	DisplayedAsHash bool
+}
+
+// SignSECP256K1Detailed behaves like SignSECP256K1, but additionally returns the
+// address and the HD path of the account the device signed with.
+func (e *EvmosSECP256K1) SignSECP256K1Detailed(hdPath []uint32, signDocBytes []byte) (SignResult, error) {
+	e.promptSigning()
+	e.reportStage(StageBuild)
+
+	typedData, err := e.buildTypedData(signDocBytes)
+	if err != nil {
+		return SignResult{}, err
+	}
+
+	var result SignResult
+
+	ctx := context.Background()
+	_, err = e.signWithContext(ctx, func(ctx context.Context) (_ []byte, err error) {
+		result, err = e.signTypedData(ctx, hdPath, typedData)
+		return result.Signature, err
+	})
+	if err != nil {
+		return SignResult{}, err
+	}
+
+	return result, nil
+}
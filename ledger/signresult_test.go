@@ -0,0 +1,141 @@
+package ledger_test
+
+import (
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestSignSECP256K1Detailed() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - can't find Ledger device",
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			false,
+		},
+		{
+			"fail - user rejected the transaction",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedDataRejected(suite.mockWallet, account, suite.txAmino)
+			},
+			false,
+		},
+		{
+			"pass - signing account reported",
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+
+			hdPath := append([]uint32(nil), gethaccounts.DefaultBaseDerivationPath...)
+			result, err := suite.ledger.SignSECP256K1Detailed(hdPath, suite.txAmino)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Len(result.Signature, crypto.SignatureLength)
+			suite.Require().Equal(addr, result.Address)
+			suite.Require().Equal([]uint32(gethaccounts.DefaultBaseDerivationPath), result.HDPath)
+
+			// The reported path is not affected by later changes of the caller
+			hdPath[4] = 1
+			suite.Require().Equal([]uint32(gethaccounts.DefaultBaseDerivationPath), result.HDPath)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestSignSECP256K1DetailedDisplayedAsHash() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	testCases := []struct {
+		name     string
+		mode     ledger.DisplayMode
+		mockFunc func()
+		expHash  bool
+	}{
+		{
+			"pass - hash-only display mode",
+			ledger.DisplayHashOnly,
+			func() {
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+			true,
+		},
+		{
+			"pass - full display unsupported, fall back to hash-only",
+			ledger.DisplayFull,
+			func() {
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: [3]byte{1, 9, 18}})
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+			true,
+		},
+		{
+			"pass - message displayed fully",
+			ledger.DisplayFull,
+			func() {
+				RegisterAppConfiguration(suite.mockWallet, accounts.AppConfiguration{Version: ledger.DefaultEIP712FullDisplayVersion})
+				RegisterSignTypedDataFull(suite.mockWallet, account, suite.txAmino, nil)
+			},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			ledger.WithDisplayMode(tc.mode)(suite.ledger)
+			RegisterOpen(suite.mockWallet)
+			RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			tc.mockFunc()
+
+			result, err := suite.ledger.SignSECP256K1Detailed(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expHash, result.DisplayedAsHash)
+			suite.mockWallet.AssertExpectations(suite.T())
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package ledger
+
+// Stage identifies the progress of a signing request, so that user interfaces can
+// render their own, possibly localized, progress indicator (see SetPromptCallback).
+type Stage int
+
+const (
+	// StageDerive is reported before the signing account is derived from the HD path.
+	StageDerive Stage = iota
+	// StageBuild is reported before the sign doc is converted into the EIP-712
+	// payload sent to the device.
+	StageBuild
+	// StageAwaitConfirmation is reported before the payload is sent to the device,
+	// which waits for the user to confirm or reject it.
+	StageAwaitConfirmation
+)
+
+// String implements fmt.Stringer.
+func (s Stage) String() string {
+	switch s {
+	case StageDerive:
+		return "derive"
+	case StageBuild:
+		return "build"
+	case StageAwaitConfirmation:
+		return "await-confirmation"
+	default:
+		return "unknown"
+	}
+}
+
+// SetPromptCallback sets a function that is called at each stage of the signing
+// requests, in the order they are reached (e.g. a sign doc is built before the
+// account is derived). If set, the default "Generating payload, please check your
+// Ledger..." prompt is not shown, so that the callback can render its own message
+// instead; the other prompts are still shown. The callback may be called while
+// the device is locked, so it must return quickly and must not call the methods of
+// EvmosSECP256K1. Passing nil restores the default prompt.
+func (e *EvmosSECP256K1) SetPromptCallback(callback func(stage Stage)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.promptCallback = callback
+}
+
+// promptSigning shows the default prompt at the start of a signing request, unless
+// a prompt callback reports the progress instead.
+func (e *EvmosSECP256K1) promptSigning() {
+	if e.promptCallback == nil {
+		e.prompt("Generating payload, please check your Ledger...")
+	}
+}
+
+// reportStage notifies the prompt callback, if any, that a signing request reached
+// the given stage.
+func (e *EvmosSECP256K1) reportStage(stage Stage) {
+	if e.promptCallback != nil {
+		e.promptCallback(stage)
+	}
+}
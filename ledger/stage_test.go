@@ -0,0 +1,84 @@
+package ledger_test
+
+import (
+	"bytes"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestPromptCallback() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	message := []byte("Sign in to Evmos")
+
+	testCases := []struct {
+		name      string
+		mockFunc  func()
+		signFunc  func() error
+		expStages []ledger.Stage
+	}{
+		{
+			"pass - sign doc",
+			func() {
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+			func() error {
+				_, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+				return err
+			},
+			[]ledger.Stage{ledger.StageBuild, ledger.StageDerive, ledger.StageAwaitConfirmation},
+		},
+		{
+			"pass - personal message",
+			func() {
+				RegisterSignText(suite.mockWallet, account, message, mockSignature(27))
+			},
+			func() error {
+				_, err := suite.ledger.SignPersonalMessage(gethaccounts.DefaultBaseDerivationPath, message)
+				return err
+			},
+			[]ledger.Stage{ledger.StageDerive, ledger.StageAwaitConfirmation},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			RegisterOpen(suite.mockWallet)
+			RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			tc.mockFunc()
+
+			var (
+				prompts bytes.Buffer
+				stages  []ledger.Stage
+			)
+			ledger.WithPromptWriter(&prompts)(suite.ledger)
+			ledger.WithPromptCallback(func(stage ledger.Stage) {
+				stages = append(stages, stage)
+			})(suite.ledger)
+
+			suite.Require().NoError(tc.signFunc())
+			suite.Require().Equal(tc.expStages, stages)
+
+			// The callback replaces the default prompt
+			suite.Require().Empty(prompts.String())
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestStageString() {
+	suite.Require().Equal("derive", ledger.StageDerive.String())
+	suite.Require().Equal("build", ledger.StageBuild.String())
+	suite.Require().Equal("await-confirmation", ledger.StageAwaitConfirmation.String())
+	suite.Require().Equal("unknown", ledger.Stage(-1).String())
+}
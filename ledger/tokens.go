@@ -0,0 +1,44 @@
+package ledger
+
+import (
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+)
+
+// SetTokenInfo sets the ERC-20 token descriptors provided to the Ledger before each
+// EIP-712 signing request, so that the Ethereum app can display the token amounts
+// of the message in a human-readable form (e.g. "100 USDC") instead of raw values.
+// The descriptors must be signed by the Ledger Crypto Asset List, or the device
+// rejects the request. Calling it without arguments stops providing descriptors,
+// which is the default.
+func (e *EvmosSECP256K1) SetTokenInfo(tokens ...accounts.TokenInfo) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(tokens) == 0 {
+		e.tokens = nil
+		return
+	}
+
+	e.tokens = append([]accounts.TokenInfo(nil), tokens...)
+}
+
+// signTypedDataWithTokens signs the typed data with the wallet of the device,
+// providing the configured token descriptors first, if any. The whole message is
+// sent to the device if the display mode and the app allow it (see SetDisplayMode),
+// otherwise displayedAsHash is set.
+//
+// Note, signTypedDataWithTokens assumes the lock is held!
+func (e *EvmosSECP256K1) signTypedDataWithTokens(account accounts.Account, typedData apitypes.TypedData) (signature []byte, displayedAsHash bool, err error) {
+	if e.fullDisplay() {
+		signature, err = e.PrimaryWallet.SignTypedDataFull(account, typedData, e.tokens)
+		return signature, false, err
+	}
+	if len(e.tokens) == 0 {
+		signature, err = e.PrimaryWallet.SignTypedData(account, typedData)
+		return signature, true, err
+	}
+	signature, err = e.PrimaryWallet.SignTypedDataWithTokens(account, typedData, e.tokens)
+	return signature, true, err
+}
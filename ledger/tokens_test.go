@@ -0,0 +1,65 @@
+package ledger_test
+
+import (
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestSetTokenInfo() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	tokens := []accounts.TokenInfo{
+		{
+			Ticker:    "USDC",
+			Address:   common.HexToAddress("0x15C3Eb3B621d1Bff62CbA1c9536B7c1AE9149b57"),
+			Decimals:  6,
+			ChainID:   9001,
+			Signature: []byte{0x30, 0x44},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		tokens   []accounts.TokenInfo
+		mockFunc func()
+	}{
+		{
+			"pass - no token information",
+			nil,
+			func() {
+				RegisterSignTypedData(suite.mockWallet, account, suite.txAmino)
+			},
+		},
+		{
+			"pass - token information provided",
+			tokens,
+			func() {
+				RegisterSignTypedDataWithTokens(suite.mockWallet, account, suite.txAmino, tokens)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			ledger.WithTokenInfo(tc.tokens...)(suite.ledger)
+			RegisterOpen(suite.mockWallet)
+			RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+			tc.mockFunc()
+
+			signature, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+			suite.Require().NoError(err)
+			suite.Require().Len(signature, crypto.SignatureLength)
+		})
+	}
+}
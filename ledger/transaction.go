@@ -0,0 +1,80 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SignTx signs a native Ethereum transaction, e.g. to call an EVM contract or a
+// precompile with the same device that signs the Cosmos transactions. Legacy
+// transactions are signed following EIP-155, or as Homestead transactions if the
+// chain ID is nil, while typed transactions (EIP-2930 and EIP-1559) require the
+// chain ID. The Ledger displays the transaction for the user to confirm, and the
+// signed transaction is returned with its V, R and S values set.
+func (e *EvmosSECP256K1) SignTx(hdPath []uint32, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if tx == nil {
+		return nil, errors.New("unable to sign with Ledger: no transaction provided")
+	}
+
+	e.promptSigning()
+
+	var signed *types.Transaction
+	_, err := e.signWithContext(context.Background(), func(ctx context.Context) (_ []byte, err error) {
+		signed, err = e.signTx(ctx, hdPath, tx, chainID)
+		return nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return signed, nil
+}
+
+// signTx signs the transaction with the account derived from hdPath.
+//
+// Note, signTx assumes the lock is held!
+func (e *EvmosSECP256K1) signTx(ctx context.Context, hdPath []uint32, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if e.PrimaryWallet == nil {
+		return nil, errors.New("unable to sign with Ledger: no wallet found")
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return nil, err
+	}
+
+	account, err := e.deriveSigner(ctx, hdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	e.reportStage(StageAwaitConfirmation)
+
+	var signed *types.Transaction
+	err = e.observeSign(func() error {
+		return e.retry(ctx, func() (err error) {
+			signed, err = e.PrimaryWallet.SignTx(account, tx, chainID)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error generating signature, please retry: %w", pathError("sign", hdPath, e.blindSigningError(err)))
+	}
+
+	if e.verifySignatures {
+		signer, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to recover the sender: %w", ErrSignerMismatch, err)
+		}
+
+		if err := e.verifySigner(ctx, hdPath, signer); err != nil {
+			return nil, err
+		}
+	}
+
+	return signed, nil
+}
@@ -0,0 +1,115 @@
+package ledger_test
+
+import (
+	"math/big"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestSignTx() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	chainID := big.NewInt(9001)
+	to := common.HexToAddress("0x0000000000000000000000000000000000000800")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(5),
+	})
+	signedTx, err := types.SignTx(tx, types.NewLondonSigner(chainID), privKey)
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name     string
+		tx       *types.Transaction
+		mockFunc func()
+		expErr   error
+		expPass  bool
+	}{
+		{
+			"fail - no transaction provided",
+			nil,
+			func() {},
+			nil,
+			false,
+		},
+		{
+			"fail - can't find Ledger device",
+			tx,
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			nil,
+			false,
+		},
+		{
+			"fail - unable to derive Ledger address",
+			tx,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDeriveError(suite.mockWallet)
+			},
+			nil,
+			false,
+		},
+		{
+			"fail - user rejected the transaction",
+			tx,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTxError(suite.mockWallet, account, tx, chainID)
+			},
+			ledger.ErrUserRejected,
+			false,
+		},
+		{
+			"pass - transaction signed",
+			tx,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTx(suite.mockWallet, account, tx, chainID, signedTx)
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			signed, err := suite.ledger.SignTx(gethaccounts.DefaultBaseDerivationPath, tc.tx, chainID)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(signedTx.Hash(), signed.Hash())
+
+				sender, err := types.Sender(types.NewLondonSigner(chainID), signed)
+				suite.Require().NoError(err)
+				suite.Require().Equal(addr, sender)
+			} else {
+				suite.Require().Error(err)
+				if tc.expErr != nil {
+					suite.Require().ErrorIs(err, tc.expErr)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// ErrSignerMismatch is returned when signature verification is enabled and the
+// signature was not produced by the key derived at the requested HD path (see
+// SetSignatureVerification).
+var ErrSignerMismatch = errors.New("signature was not produced by the derived key")
+
+// SetSignatureVerification enables or disables checking every signature against
+// the device. If enabled, once the device has signed, the public key is recovered
+// from the signature and the signed hash, the account is derived again from the HD
+// path, and ErrSignerMismatch is returned if they differ, which catches a tampered
+// device or a path confusion signing with an unexpected key. It costs an extra
+// round-trip to the device per signature, and is disabled by default. Signatures
+// made with an already derived account (see SignSECP256K1WithAccount) are not
+// verified, since no HD path is known to derive the account from.
+func (e *EvmosSECP256K1) SetSignatureVerification(verify bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.verifySignatures = verify
+}
+
+// verifySignature checks that the 65-byte signature over the hash was produced by
+// the key derived at the given HD path, if signature verification is enabled.
+//
+// Note, verifySignature assumes the lock is held!
+func (e *EvmosSECP256K1) verifySignature(ctx context.Context, hdPath []uint32, hash, signature []byte) error {
+	if !e.verifySignatures {
+		return nil
+	}
+
+	sig, err := ParseSignature(signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSignerMismatch, err)
+	}
+
+	pubKey, err := crypto.SigToPub(hash, append(sig.Bytes64(), sig.RecoveryID()))
+	if err != nil {
+		return fmt.Errorf("%w: unable to recover the public key: %w", ErrSignerMismatch, err)
+	}
+
+	return e.verifySigner(ctx, hdPath, crypto.PubkeyToAddress(*pubKey))
+}
+
+// verifyTypedDataSignature checks that the signature of the EIP-712 typed data was
+// produced by the key derived at the given HD path, if signature verification is
+// enabled.
+//
+// Note, verifyTypedDataSignature assumes the lock is held!
+func (e *EvmosSECP256K1) verifyTypedDataSignature(ctx context.Context, hdPath []uint32, typedData apitypes.TypedData, signature []byte) error {
+	if !e.verifySignatures {
+		return nil
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return fmt.Errorf("unable to generate EIP-712 hash for object: %w", err)
+	}
+
+	return e.verifySignature(ctx, hdPath, hash, signature)
+}
+
+// verifySigner checks that the address of the key derived at the given HD path is
+// the signer one, if signature verification is enabled. The account is derived
+// again from the device, bypassing the cache.
+//
+// Note, verifySigner assumes the lock is held!
+func (e *EvmosSECP256K1) verifySigner(ctx context.Context, hdPath []uint32, signer common.Address) error {
+	if !e.verifySignatures {
+		return nil
+	}
+
+	account, err := e.derive(ctx, hdPath, false)
+	if err != nil {
+		return fmt.Errorf("unable to derive Ledger address to verify the signature: %w", err)
+	}
+
+	if account.Address != signer {
+		return fmt.Errorf("%w: signed by %s instead of %s at %s", ErrSignerMismatch,
+			signer.Hex(), account.Address.Hex(), gethaccounts.DerivationPath(hdPath))
+	}
+
+	return nil
+}
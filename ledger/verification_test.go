@@ -0,0 +1,135 @@
+package ledger_test
+
+import (
+	"crypto/ecdsa"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+	"github.com/evmos/evmos/v14/ethereum/eip712"
+)
+
+func (suite *LedgerTestSuite) TestSignatureVerification() {
+	privKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(privKey.PublicKey)
+	account := accounts.Account{
+		Address:   addr,
+		PublicKey: &privKey.PublicKey,
+	}
+
+	otherKey, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+
+	message := []byte("Sign in to Evmos")
+	sign := func(hash []byte, key *ecdsa.PrivateKey) []byte {
+		signature, err := crypto.Sign(hash, key)
+		suite.Require().NoError(err)
+		signature[crypto.RecoveryIDOffset] += 27
+		return signature
+	}
+
+	typedData, err := eip712.GetEIP712TypedDataForMsg(suite.txAmino)
+	suite.Require().NoError(err)
+	typedDataHash, _, err := apitypes.TypedDataAndHash(typedData)
+	suite.Require().NoError(err)
+
+	signMessage := func() error {
+		_, err := suite.ledger.SignPersonalMessage(gethaccounts.DefaultBaseDerivationPath, message)
+		return err
+	}
+	signTypedData := func() error {
+		_, err := suite.ledger.SignSECP256K1(gethaccounts.DefaultBaseDerivationPath, suite.txAmino)
+		return err
+	}
+
+	testCases := []struct {
+		name     string
+		verify   bool
+		mockFunc func()
+		signFunc func() error
+		expPass  bool
+	}{
+		{
+			"pass - message signed by the derived key",
+			true,
+			func() {
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignText(suite.mockWallet, account, message, sign(gethaccounts.TextHash(message), privKey))
+			},
+			signMessage,
+			true,
+		},
+		{
+			"pass - typed data signed by the derived key",
+			true,
+			func() {
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedDataWithSignature(suite.mockWallet, account, suite.txAmino, sign(typedDataHash, privKey))
+			},
+			signTypedData,
+			true,
+		},
+		{
+			"pass - verification disabled",
+			false,
+			func() {
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignText(suite.mockWallet, account, message, sign(gethaccounts.TextHash(message), otherKey))
+			},
+			signMessage,
+			true,
+		},
+		{
+			"fail - message signed by another key",
+			true,
+			func() {
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignText(suite.mockWallet, account, message, sign(gethaccounts.TextHash(message), otherKey))
+			},
+			signMessage,
+			false,
+		},
+		{
+			"fail - typed data signed by another key",
+			true,
+			func() {
+				RegisterDerive(suite.mockWallet, addr, &privKey.PublicKey)
+				RegisterSignTypedDataWithSignature(suite.mockWallet, account, suite.txAmino, sign(typedDataHash, otherKey))
+			},
+			signTypedData,
+			false,
+		},
+		{
+			"fail - account derived again with another key",
+			true,
+			func() {
+				suite.mockWallet.On("Derive", gethaccounts.DefaultBaseDerivationPath, true).
+					Return(account, nil).Once()
+				RegisterDerive(suite.mockWallet, crypto.PubkeyToAddress(otherKey.PublicKey), &otherKey.PublicKey)
+				RegisterSignText(suite.mockWallet, account, message, sign(gethaccounts.TextHash(message), privKey))
+			},
+			signMessage,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			RegisterOpen(suite.mockWallet)
+			tc.mockFunc()
+			ledger.WithSignatureVerification(tc.verify)(suite.ledger)
+
+			err := tc.signFunc()
+			if tc.expPass {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().ErrorIs(err, ledger.ErrSignerMismatch)
+			}
+		})
+	}
+}
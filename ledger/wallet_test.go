@@ -3,11 +3,21 @@ package ledger_test
 import (
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
 
 	gethaccounts "github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/mock"
+
 	"github.com/evmos/evmos-ledger-go/accounts"
 	"github.com/evmos/evmos-ledger-go/ledger/mocks"
+	"github.com/evmos/evmos-ledger-go/usbwallet"
 	"github.com/evmos/evmos/v14/ethereum/eip712"
 )
 
@@ -16,25 +26,116 @@ func RegisterDerive(mockWallet *mocks.Wallet, addr common.Address, publicKey *ec
 		Return(accounts.Account{Address: addr, PublicKey: publicKey}, nil)
 }
 
+func RegisterDeriveForPath(mockWallet *mocks.Wallet, path gethaccounts.DerivationPath, addr common.Address, publicKey *ecdsa.PublicKey) {
+	mockWallet.On("Derive", path, true).
+		Return(accounts.Account{Address: addr, PublicKey: publicKey}, nil)
+}
+
+// RegisterDeriveExclusive registers a Derive call that records whether it was
+// ever invoked while another Derive call was still in progress.
+func RegisterDeriveExclusive(mockWallet *mocks.Wallet, addr common.Address, publicKey *ecdsa.PublicKey, overlapped *atomic.Bool) {
+	var inFlight atomic.Int32
+	mockWallet.On("Derive", gethaccounts.DefaultBaseDerivationPath, true).
+		Run(func(mock.Arguments) {
+			if inFlight.Add(1) > 1 {
+				overlapped.Store(true)
+			}
+			time.Sleep(time.Millisecond)
+			inFlight.Add(-1)
+		}).
+		Return(accounts.Account{Address: addr, PublicKey: publicKey}, nil)
+}
+
+func RegisterDeriveWithDisplay(mockWallet *mocks.Wallet, addr common.Address, publicKey *ecdsa.PublicKey) {
+	mockWallet.On("DeriveWithDisplay", gethaccounts.DefaultBaseDerivationPath, true).
+		Return(accounts.Account{Address: addr, PublicKey: publicKey}, nil)
+}
+
+func RegisterDeriveWithDisplayRejected(mockWallet *mocks.Wallet) {
+	mockWallet.On("DeriveWithDisplay", gethaccounts.DefaultBaseDerivationPath, true).
+		Return(accounts.Account{}, &usbwallet.APDUError{StatusWord: 0x6985})
+}
+
 func RegisterDeriveError(mockWallet *mocks.Wallet) {
 	mockWallet.On("Derive", gethaccounts.DefaultBaseDerivationPath, true).
 		Return(accounts.Account{}, errors.New("unable to derive Ledger address, please open the Ethereum app and retry"))
 }
 
+func RegisterDeriveTransientError(mockWallet *mocks.Wallet) {
+	mockWallet.On("Derive", gethaccounts.DefaultBaseDerivationPath, true).
+		Return(accounts.Account{}, fmt.Errorf("%w: %w", usbwallet.ErrDeviceIO, errors.New("hidapi: read timeout"))).
+		Once()
+}
+
+func RegisterDeriveShortResponse(mockWallet *mocks.Wallet) {
+	mockWallet.On("Derive", gethaccounts.DefaultBaseDerivationPath, true).
+		Return(accounts.Account{}, fmt.Errorf("%w: reply lacks public key entry", usbwallet.ErrShortResponse))
+}
+
+func RegisterDeriveAPDUError(mockWallet *mocks.Wallet, statusWord uint16) {
+	mockWallet.On("Derive", gethaccounts.DefaultBaseDerivationPath, true).
+		Return(accounts.Account{}, &usbwallet.APDUError{StatusWord: statusWord})
+}
+
 func RegisterOpen(mockWallet *mocks.Wallet) {
 	mockWallet.On("Open", "").
 		Return(nil)
 }
 
+func RegisterOpenError(mockWallet *mocks.Wallet, err error) {
+	mockWallet.On("Open", "").
+		Return(err)
+}
+
+func RegisterOpenBusy(mockWallet *mocks.Wallet) {
+	mockWallet.On("Open", "").
+		Return(fmt.Errorf("%w: %w", usbwallet.ErrDeviceBusy, errors.New("hidapi: failed to open device"))).
+		Once()
+}
+
+func RegisterStatus(mockWallet *mocks.Wallet, status string) {
+	mockWallet.On("Status").
+		Return(status, nil)
+}
+
 func RegisterClose(mockWallet *mocks.Wallet) {
 	mockWallet.On("Close").
 		Return(nil)
 }
 
+// mockSignature returns a well-formed 65-byte [R || S || V] signature, with V
+// set to the given value.
+func mockSignature(v byte) []byte {
+	signature := make([]byte, crypto.SignatureLength)
+	signature[crypto.RecoveryIDOffset] = v
+	return signature
+}
+
 func RegisterSignTypedData(mockWallet *mocks.Wallet, account accounts.Account, typedDataBz []byte) {
+	RegisterSignTypedDataWithSignature(mockWallet, account, typedDataBz, mockSignature(27))
+}
+
+func RegisterSignTypedDataWithSignature(mockWallet *mocks.Wallet, account accounts.Account, typedDataBz []byte, signature []byte) {
+	typedData, _ := eip712.GetEIP712TypedDataForMsg(typedDataBz)
+	mockWallet.On("SignTypedData", account, typedData).
+		Return(signature, nil)
+}
+
+func RegisterSignTypedDataWithTokens(mockWallet *mocks.Wallet, account accounts.Account, typedDataBz []byte, tokens []accounts.TokenInfo) {
 	typedData, _ := eip712.GetEIP712TypedDataForMsg(typedDataBz)
+	mockWallet.On("SignTypedDataWithTokens", account, typedData, tokens).
+		Return(mockSignature(27), nil)
+}
+
+func RegisterSignTypedDataFull(mockWallet *mocks.Wallet, account accounts.Account, typedDataBz []byte, tokens []accounts.TokenInfo) {
+	typedData, _ := eip712.GetEIP712TypedDataForMsg(typedDataBz)
+	mockWallet.On("SignTypedDataFull", account, typedData, tokens).
+		Return(mockSignature(27), nil)
+}
+
+func RegisterSignTypedDataObject(mockWallet *mocks.Wallet, account accounts.Account, typedData apitypes.TypedData) {
 	mockWallet.On("SignTypedData", account, typedData).
-		Return([]byte{}, nil)
+		Return(mockSignature(27), nil)
 }
 
 func RegisterSignTypedDataError(mockWallet *mocks.Wallet, account accounts.Account, typedDataBz []byte) {
@@ -42,3 +143,98 @@ func RegisterSignTypedDataError(mockWallet *mocks.Wallet, account accounts.Accou
 	mockWallet.On("SignTypedData", account, typedData).
 		Return([]byte{}, errors.New("error generating signature, please retry"))
 }
+
+func RegisterSignTypedDataDelay(mockWallet *mocks.Wallet, account accounts.Account, typedDataBz []byte, delay time.Duration) {
+	typedData, _ := eip712.GetEIP712TypedDataForMsg(typedDataBz)
+	mockWallet.On("SignTypedData", account, typedData).
+		After(delay).
+		Return(mockSignature(27), nil)
+}
+
+func RegisterSignTypedDataAPDUError(mockWallet *mocks.Wallet, account accounts.Account, typedDataBz []byte, statusWord uint16) {
+	typedData, _ := eip712.GetEIP712TypedDataForMsg(typedDataBz)
+	mockWallet.On("SignTypedData", account, typedData).
+		Return(nil, &usbwallet.APDUError{StatusWord: statusWord})
+}
+
+func RegisterURL(mockWallet *mocks.Wallet, url gethaccounts.URL) {
+	mockWallet.On("URL").
+		Return(url)
+}
+
+// recordingLogger is a ledger.Logger that stores every message it receives.
+type recordingLogger struct {
+	infos  []string
+	debugs []string
+}
+
+func (l *recordingLogger) Infof(format string, args ...interface{}) {
+	l.infos = append(l.infos, fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) Debugf(format string, args ...interface{}) {
+	l.debugs = append(l.debugs, fmt.Sprintf(format, args...))
+}
+
+func RegisterSignTypedDataRejected(mockWallet *mocks.Wallet, account accounts.Account, typedDataBz []byte) {
+	typedData, _ := eip712.GetEIP712TypedDataForMsg(typedDataBz)
+	mockWallet.On("SignTypedData", account, typedData).
+		Return(nil, &usbwallet.APDUError{StatusWord: 0x6985})
+}
+
+func RegisterSignText(mockWallet *mocks.Wallet, account accounts.Account, text []byte, signature []byte) {
+	mockWallet.On("SignText", account, text).
+		Return(signature, nil)
+}
+
+func RegisterSignTextError(mockWallet *mocks.Wallet, account accounts.Account, text []byte) {
+	mockWallet.On("SignText", account, text).
+		Return(nil, &usbwallet.APDUError{StatusWord: 0x6985})
+}
+
+func RegisterSignTextDelay(mockWallet *mocks.Wallet, account accounts.Account, text []byte, delay time.Duration) {
+	mockWallet.On("SignText", account, text).
+		After(delay).
+		Return(mockSignature(27), nil)
+}
+
+func RegisterSignTx(mockWallet *mocks.Wallet, account accounts.Account, tx *types.Transaction, chainID *big.Int, signed *types.Transaction) {
+	mockWallet.On("SignTx", account, tx, chainID).
+		Return(signed, nil)
+}
+
+func RegisterSignTxError(mockWallet *mocks.Wallet, account accounts.Account, tx *types.Transaction, chainID *big.Int) {
+	mockWallet.On("SignTx", account, tx, chainID).
+		Return(nil, &usbwallet.APDUError{StatusWord: 0x6985})
+}
+
+func RegisterSignTxDelay(mockWallet *mocks.Wallet, account accounts.Account, tx *types.Transaction, chainID *big.Int, delay time.Duration) {
+	mockWallet.On("SignTx", account, tx, chainID).
+		After(delay).
+		Return(tx, nil)
+}
+
+func RegisterInfo(mockWallet *mocks.Wallet, info accounts.DeviceInfo) {
+	mockWallet.On("Info").
+		Return(info)
+}
+
+func RegisterAppConfiguration(mockWallet *mocks.Wallet, config accounts.AppConfiguration) {
+	mockWallet.On("AppConfiguration").
+		Return(config, nil)
+}
+
+func RegisterAppConfigurationError(mockWallet *mocks.Wallet, statusWord uint16) {
+	mockWallet.On("AppConfiguration").
+		Return(accounts.AppConfiguration{}, &usbwallet.APDUError{StatusWord: statusWord})
+}
+
+func RegisterRunningApp(mockWallet *mocks.Wallet, name string) {
+	mockWallet.On("RunningApp").
+		Return(accounts.AppInfo{Name: name, Version: "1.10.2"}, nil)
+}
+
+func RegisterRunningAppError(mockWallet *mocks.Wallet, statusWord uint16) {
+	mockWallet.On("RunningApp").
+		Return(accounts.AppInfo{}, &usbwallet.APDUError{StatusWord: statusWord})
+}
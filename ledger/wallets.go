@@ -0,0 +1,137 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/usbwallet"
+)
+
+// WalletInfo describes a hardware wallet detected by the hub.
+type WalletInfo struct {
+	Index        int    `json:"index"`        // Position of the wallet in the list returned by the hub
+	URL          string `json:"url"`          // Canonical URL of the wallet, e.g. "ledger://0001:0004:00"
+	Path         string `json:"path"`         // Platform-specific path of the USB device
+	VendorID     uint16 `json:"vendorId"`     // USB vendor identifier
+	ProductID    uint16 `json:"productId"`    // USB product identifier
+	Manufacturer string `json:"manufacturer"` // Manufacturer string reported by the device, e.g. "Ledger"
+	Product      string `json:"product"`      // Product string reported by the device, e.g. "Nano X"
+	Serial       string `json:"serial"`       // Serial number string reported by the device, if any
+	Status       string `json:"status"`       // Textual status of the wallet, e.g. "Closed"
+	AppOpen      bool   `json:"appOpen"`      // Whether the Ethereum app appears to be open
+}
+
+// newWalletInfo builds the WalletInfo describing the wallet found at the given index.
+func newWalletInfo(index int, wallet accounts.Wallet) WalletInfo {
+	info := wallet.Info()
+	status, err := wallet.Status()
+
+	return WalletInfo{
+		Index:        index,
+		URL:          wallet.URL().String(),
+		Path:         info.Path,
+		VendorID:     info.VendorID,
+		ProductID:    info.ProductID,
+		Manufacturer: info.Manufacturer,
+		Product:      info.Product,
+		Serial:       info.Serial,
+		Status:       status,
+		// The Ledger driver reports "Ethereum app vX.Y.Z online" once it could reach
+		// the app. Wallets that were not opened yet are reported as "Closed".
+		AppOpen: err == nil && strings.HasSuffix(status, "online"),
+	}
+}
+
+// newWalletInfos builds the WalletInfo list describing the given wallets.
+func newWalletInfos(wallets []accounts.Wallet) []WalletInfo {
+	infos := make([]WalletInfo, len(wallets))
+	for i, wallet := range wallets {
+		infos[i] = newWalletInfo(i, wallet)
+	}
+
+	return infos
+}
+
+// ListLedgerWallets scans the USB devices attached to the machine and returns the
+// Ledger wallets found, without opening any of them. The indexes of the returned
+// wallets can be used with EvmosLedgerDerivationForDevice.
+func ListLedgerWallets() ([]WalletInfo, error) {
+	hub, err := usbwallet.NewLedgerHub()
+	if err != nil {
+		return nil, err
+	}
+
+	return newWalletInfos(hub.Wallets()), nil
+}
+
+// ListWallets returns the hardware wallets currently detected by the hub, in the
+// same order used for index-based selection.
+func (e *EvmosSECP256K1) ListWallets() ([]WalletInfo, error) {
+	if e.Hub == nil {
+		return nil, errors.New("could not list Ledger devices: no hardware wallet hub found")
+	}
+
+	return newWalletInfos(e.Wallets()), nil
+}
+
+// SelectWallet opens the wallet identified by urlOrIndex and makes it the primary
+// wallet. The identifier can either be the wallet URL, the USB device path or the
+// index of the wallet as returned by ListWallets. The previously selected wallet,
+// if any, is closed.
+func (e *EvmosSECP256K1) SelectWallet(urlOrIndex string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.Hub == nil {
+		return errors.New("could not select Ledger device: no hardware wallet hub found")
+	}
+
+	wallets := e.Wallets()
+
+	selected, err := findWallet(wallets, urlOrIndex)
+	if err != nil {
+		return err
+	}
+
+	if e.PrimaryWallet != nil && e.PrimaryWallet.URL().Cmp(selected.URL()) == 0 {
+		return nil
+	}
+
+	if err := e.openWallet(context.Background(), selected); err != nil {
+		return err
+	}
+
+	if e.PrimaryWallet != nil {
+		//#nosec G703 -- the previous wallet is no longer used, so a failure to close it is not relevant
+		_ = e.PrimaryWallet.Close()
+	}
+
+	e.PrimaryWallet = selected
+	e.clearCache()
+
+	return nil
+}
+
+// findWallet returns the wallet matching the given URL, USB path or index.
+func findWallet(wallets []accounts.Wallet, urlOrIndex string) (accounts.Wallet, error) {
+	for _, wallet := range wallets {
+		if wallet.URL().String() == urlOrIndex || wallet.Info().Path == urlOrIndex {
+			return wallet, nil
+		}
+	}
+
+	index, err := strconv.Atoi(urlOrIndex)
+	if err != nil {
+		return nil, fmt.Errorf("no hardware wallet found matching %q", urlOrIndex)
+	}
+
+	if index < 0 || index >= len(wallets) {
+		return nil, fmt.Errorf("no hardware wallet found at index %d (%d detected)", index, len(wallets))
+	}
+
+	return wallets[index], nil
+}
@@ -0,0 +1,93 @@
+package ledger_test
+
+import (
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestListWallets() {
+	testCases := []struct {
+		name     string
+		mockFunc func()
+		expPass  bool
+	}{
+		{
+			"fail - no hardware wallet hub",
+			func() {
+				suite.ledger.Hub = nil
+			},
+			false,
+		},
+		{
+			"pass - no hardware wallets detected",
+			func() {},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			wallets, err := suite.ledger.ListWallets()
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Empty(wallets)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestListLedgerWallets() {
+	wallets, err := ledger.ListLedgerWallets()
+	suite.Require().NoError(err)
+	suite.Require().Empty(wallets)
+}
+
+func (suite *LedgerTestSuite) TestSelectWallet() {
+	testCases := []struct {
+		name       string
+		urlOrIndex string
+		mockFunc   func()
+	}{
+		{
+			"fail - no hardware wallet hub",
+			"0",
+			func() {
+				suite.ledger.Hub = nil
+			},
+		},
+		{
+			"fail - index out of range",
+			"1",
+			func() {},
+		},
+		{
+			"fail - negative index",
+			"-1",
+			func() {},
+		},
+		{
+			"fail - unknown URL",
+			"ledger://unknown",
+			func() {},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			err := suite.ledger.SelectWallet(tc.urlOrIndex)
+			suite.Require().Error(err)
+			suite.Require().Equal(suite.mockWallet, suite.ledger.PrimaryWallet)
+		})
+	}
+}
+
+func (suite *LedgerTestSuite) TestEvmosLedgerDerivationForDevice() {
+	derivationFunc := ledger.EvmosLedgerDerivationForDevice(1)
+	_, err := derivationFunc()
+	suite.Require().Error(err)
+}
@@ -0,0 +1,151 @@
+package ledger
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/btcutil/base58"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+)
+
+const (
+	// bip44AccountPathLength is the number of components of a BIP-44 account path:
+	// m / purpose' / coin_type' / account'
+	bip44AccountPathLength = 3
+
+	// chainCodeLength is the length of BIP-32 chain codes.
+	chainCodeLength = 32
+)
+
+// xpubVersion is the BIP-32 version prefix of mainnet extended public keys ("xpub").
+var xpubVersion = [4]byte{0x04, 0x88, 0xb2, 0x1e}
+
+// ExportExtendedPublicKey derives the account-level node at the given BIP-44
+// account path (e.g. m/44'/60'/0') and returns its BIP-32 extended public key
+// ("xpub"). Watch-only wallets can derive the addresses of the account from it
+// (i.e. m/44'/60'/0'/0/n) without the device. The components of the path are
+// always hardened, as required for account-level nodes.
+func (e *EvmosSECP256K1) ExportExtendedPublicKey(accountPath []uint32) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.PrimaryWallet == nil {
+		return "", errors.New("could not export extended public key: no wallet found")
+	}
+
+	hdPath, err := e.validateAccountPath(accountPath)
+	if err != nil {
+		return "", err
+	}
+
+	// Re-open wallet in case it was closed
+	if err := e.open(); err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+
+	// The parent public key is required for the fingerprint of the extended key
+	parent, _, err := e.deriveWithChainCode(ctx, hdPath[:len(hdPath)-1])
+	if err != nil {
+		return "", fmt.Errorf("unable to derive parent node, please open the Ethereum app and retry: %w", err)
+	}
+
+	account, chainCode, err := e.deriveWithChainCode(ctx, hdPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to derive account node, please open the Ethereum app and retry: %w", err)
+	}
+
+	return encodeExtendedPublicKey(hdPath, parent, account, chainCode)
+}
+
+// validateAccountPath checks that the path is a BIP-44 account path using the
+// configured coin type, and returns a copy of it with all components hardened.
+//
+// Note, validateAccountPath assumes the lock is held!
+func (e *EvmosSECP256K1) validateAccountPath(accountPath []uint32) ([]uint32, error) {
+	if len(accountPath) != bip44AccountPathLength {
+		return nil, fmt.Errorf("%w: %s has %d components instead of %d", ErrInvalidHDPath,
+			gethaccounts.DerivationPath(accountPath), len(accountPath), bip44AccountPathLength)
+	}
+
+	hdPath := make([]uint32, len(accountPath))
+	for i, component := range accountPath {
+		hdPath[i] = unhardened(component) + hardenedOffset
+	}
+
+	if unhardened(hdPath[0]) != bip44Purpose {
+		return nil, fmt.Errorf("%w: %s uses purpose %d instead of %d", ErrInvalidHDPath,
+			gethaccounts.DerivationPath(hdPath), unhardened(hdPath[0]), bip44Purpose)
+	}
+
+	if err := e.validateCoinType(hdPath); err != nil {
+		return nil, err
+	}
+
+	return hdPath, nil
+}
+
+// deriveWithChainCode derives the node at the given HD path along with its chain
+// code, retrying transient failures.
+//
+// Note, deriveWithChainCode assumes the lock is held!
+func (e *EvmosSECP256K1) deriveWithChainCode(ctx context.Context, hdPath []uint32) (accounts.Account, []byte, error) {
+	var (
+		account   accounts.Account
+		chainCode []byte
+	)
+
+	err := e.observeDerive(func() error {
+		return e.retry(ctx, func() (err error) {
+			account, chainCode, err = e.PrimaryWallet.DeriveWithChainCode(hdPath)
+			return err
+		})
+	})
+	if err != nil {
+		return accounts.Account{}, nil, pathError("derive", hdPath, err)
+	}
+
+	if account.PublicKey == nil {
+		return accounts.Account{}, nil, pathError("derive", hdPath, ErrMissingPublicKey)
+	}
+
+	return account, chainCode, nil
+}
+
+// encodeExtendedPublicKey serializes the node derived at the given HD path into a
+// base58check-encoded BIP-32 extended public key.
+func encodeExtendedPublicKey(hdPath []uint32, parent, account accounts.Account, chainCode []byte) (string, error) {
+	if parent.PublicKey == nil || account.PublicKey == nil {
+		return "", errors.New("invalid extended key: missing public key")
+	}
+
+	if len(chainCode) != chainCodeLength {
+		return "", fmt.Errorf("invalid extended key: chain code has %d bytes instead of %d", len(chainCode), chainCodeLength)
+	}
+
+	// The fingerprint is the first 4 bytes of RIPEMD160(SHA256(parent public key))
+	parentKey := &secp256k1.PubKey{Key: crypto.CompressPubkey(parent.PublicKey)}
+	fingerprint := parentKey.Address()[:4]
+
+	// version (4) | depth (1) | fingerprint (4) | child number (4) | chain code (32) | key (33)
+	payload := make([]byte, 0, 78)
+	payload = append(payload, xpubVersion[:]...)
+	payload = append(payload, byte(len(hdPath)))
+	payload = append(payload, fingerprint...)
+	payload = binary.BigEndian.AppendUint32(payload, hdPath[len(hdPath)-1])
+	payload = append(payload, chainCode...)
+	payload = append(payload, crypto.CompressPubkey(account.PublicKey)...)
+
+	first := sha256.Sum256(payload)
+	checksum := sha256.Sum256(first[:])
+
+	return base58.Encode(append(payload, checksum[:4]...)), nil
+}
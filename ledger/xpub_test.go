@@ -0,0 +1,145 @@
+package ledger_test
+
+import (
+	"encoding/hex"
+	"errors"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos-ledger-go/accounts"
+	"github.com/evmos/evmos-ledger-go/ledger"
+)
+
+func (suite *LedgerTestSuite) TestExportExtendedPublicKey() {
+	// Nodes m/0'/1 and m/0'/1/2' of the BIP-32 test vector 1, whose depth and child
+	// number match the ones of the m/44'/60'/2' account path
+	decodeKey := func(hexKey string) accounts.Account {
+		bz, err := hex.DecodeString(hexKey)
+		suite.Require().NoError(err)
+		pubKey, err := crypto.DecompressPubkey(bz)
+		suite.Require().NoError(err)
+		return accounts.Account{Address: crypto.PubkeyToAddress(*pubKey), PublicKey: pubKey}
+	}
+	parent := decodeKey("03501e454bf00751f24b1b489aa925215d66af2234e3891c3b21a52bedb3cd711c")
+	account := decodeKey("0357bfe1e341d01c69fe5654309956cbea516822fba8a601743a012a7896ee8dc2")
+	chainCode, err := hex.DecodeString("04466b9cc8e161e966409ca52986c584f07e9dc81f735db683c3ff6ec7b1503f")
+	suite.Require().NoError(err)
+	expXPub := "xpub6D4BDPcP2GT577Vvch3R8wDkScZWzQzMMUm3PWbmWvVJrZwQY4VUNgqFJPMM3No2dFDFGTsxxpG5uJh7n7epu4trkrX7x7DogT5Uv6fcLW5"
+
+	parentPath := gethaccounts.DerivationPath{0x80000000 + 44, 0x80000000 + 60}
+	accountPath := gethaccounts.DerivationPath{0x80000000 + 44, 0x80000000 + 60, 0x80000000 + 2}
+
+	testCases := []struct {
+		name     string
+		path     []uint32
+		mockFunc func()
+		expPass  bool
+		expErr   error
+	}{
+		{
+			"fail - can't find Ledger device",
+			accountPath,
+			func() {
+				suite.ledger.PrimaryWallet = nil
+			},
+			false,
+			nil,
+		},
+		{
+			"fail - not an account path",
+			gethaccounts.DefaultBaseDerivationPath,
+			func() {},
+			false,
+			ledger.ErrInvalidHDPath,
+		},
+		{
+			"fail - wrong coin type",
+			[]uint32{0x80000000 + 44, 0x80000000 + 118, 0x80000000 + 2},
+			func() {},
+			false,
+			ledger.ErrInvalidHDPath,
+		},
+		{
+			"fail - unable to derive account node",
+			accountPath,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				suite.mockWallet.On("DeriveWithChainCode", parentPath).
+					Return(parent, chainCode, nil)
+				suite.mockWallet.On("DeriveWithChainCode", accountPath).
+					Return(accounts.Account{}, nil, errors.New("unable to derive"))
+			},
+			false,
+			nil,
+		},
+		{
+			"fail - no public key returned by Ledger",
+			accountPath,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				suite.mockWallet.On("DeriveWithChainCode", parentPath).
+					Return(accounts.Account{Address: parent.Address}, chainCode, nil)
+			},
+			false,
+			ledger.ErrMissingPublicKey,
+		},
+		{
+			"fail - invalid chain code",
+			accountPath,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				suite.mockWallet.On("DeriveWithChainCode", parentPath).
+					Return(parent, chainCode, nil)
+				suite.mockWallet.On("DeriveWithChainCode", accountPath).
+					Return(account, chainCode[:16], nil)
+			},
+			false,
+			nil,
+		},
+		{
+			"pass - extended public key returned",
+			accountPath,
+			func() {
+				RegisterOpen(suite.mockWallet)
+				suite.mockWallet.On("DeriveWithChainCode", parentPath).
+					Return(parent, chainCode, nil)
+				suite.mockWallet.On("DeriveWithChainCode", accountPath).
+					Return(account, chainCode, nil)
+			},
+			true,
+			nil,
+		},
+		{
+			"pass - unhardened components are hardened",
+			[]uint32{44, 60, 2},
+			func() {
+				RegisterOpen(suite.mockWallet)
+				suite.mockWallet.On("DeriveWithChainCode", parentPath).
+					Return(parent, chainCode, nil)
+				suite.mockWallet.On("DeriveWithChainCode", accountPath).
+					Return(account, chainCode, nil)
+			},
+			true,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.mockFunc()
+			xpub, err := suite.ledger.ExportExtendedPublicKey(tc.path)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				if tc.expErr != nil {
+					suite.Require().ErrorIs(err, tc.expErr)
+				}
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(expXPub, xpub)
+		})
+	}
+}
@@ -0,0 +1,482 @@
+package usbwallet
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+
+	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Field types of the EIP-712 struct definitions sent to the Ledger.
+const (
+	eip712TypeCustom     byte = 0 // Struct defined in the typed data
+	eip712TypeInt        byte = 1 // Signed integer, e.g. int256
+	eip712TypeUint       byte = 2 // Unsigned integer, e.g. uint256
+	eip712TypeAddress    byte = 3 // 20-byte address
+	eip712TypeBool       byte = 4 // Boolean
+	eip712TypeString     byte = 5 // UTF-8 string
+	eip712TypeFixedBytes byte = 6 // Fixed size bytes, e.g. bytes32
+	eip712TypeBytes      byte = 7 // Dynamic size bytes
+
+	eip712TypeArrayFlag byte = 0x80 // Set in the type descriptor of array fields
+	eip712TypeSizeFlag  byte = 0x40 // Set in the type descriptor of sized types
+
+	eip712ArrayDynamic byte = 0 // Array level of dynamic size, e.g. uint256[]
+	eip712ArrayFixed   byte = 1 // Array level of fixed size, e.g. uint256[2]
+
+	// eip712ChunkSize is the maximum size of the APDU data carrying a field value.
+	eip712ChunkSize = 0xff
+)
+
+var (
+	// eip712TypeRegexp splits a field type into its base type and array levels.
+	eip712TypeRegexp = regexp.MustCompile(`^([^\[\]]+)((?:\[\d*\])*)$`)
+
+	// eip712ArrayLevelRegexp matches the array levels of a field type.
+	eip712ArrayLevelRegexp = regexp.MustCompile(`\[(\d*)\]`)
+
+	// eip712SizedTypeRegexp splits sized primitive types into their name and size.
+	eip712SizedTypeRegexp = regexp.MustCompile(`^(int|uint|bytes)(\d*)$`)
+)
+
+// eip712FieldType is a field type of an EIP-712 struct, e.g. "uint256[]".
+type eip712FieldType struct {
+	name   string // Base type, e.g. "uint256" or the name of a struct
+	code   byte   // Field type of the base type
+	size   int    // Size in bytes of sized types, 0 otherwise
+	levels []int  // Sizes of the array levels from the outermost one, -1 if dynamic
+}
+
+// parseEIP712FieldType parses the type of an EIP-712 struct field.
+func parseEIP712FieldType(typ string, types apitypes.Types) (eip712FieldType, error) {
+	match := eip712TypeRegexp.FindStringSubmatch(typ)
+	if match == nil {
+		return eip712FieldType{}, fmt.Errorf("invalid EIP-712 type %q", typ)
+	}
+	fieldType := eip712FieldType{name: match[1]}
+
+	// Array levels are written from the innermost one, e.g. uint256[2][] is a
+	// dynamic array of arrays of size 2
+	levels := eip712ArrayLevelRegexp.FindAllStringSubmatch(match[2], -1)
+	for i := len(levels) - 1; i >= 0; i-- {
+		size := -1
+		if levels[i][1] != "" {
+			n, err := strconv.Atoi(levels[i][1])
+			if err != nil || n > 0xff {
+				return eip712FieldType{}, fmt.Errorf("invalid array size in EIP-712 type %q", typ)
+			}
+			size = n
+		}
+		fieldType.levels = append(fieldType.levels, size)
+	}
+
+	if _, ok := types[fieldType.name]; ok {
+		fieldType.code = eip712TypeCustom
+		return fieldType, nil
+	}
+
+	switch fieldType.name {
+	case "address":
+		fieldType.code = eip712TypeAddress
+		return fieldType, nil
+	case "bool":
+		fieldType.code = eip712TypeBool
+		return fieldType, nil
+	case "string":
+		fieldType.code = eip712TypeString
+		return fieldType, nil
+	case "bytes":
+		fieldType.code = eip712TypeBytes
+		return fieldType, nil
+	}
+
+	sized := eip712SizedTypeRegexp.FindStringSubmatch(fieldType.name)
+	if sized == nil || sized[1] == "bytes" && sized[2] == "" {
+		return eip712FieldType{}, fmt.Errorf("unknown EIP-712 type %q", typ)
+	}
+
+	switch sized[1] {
+	case "int", "uint":
+		bits := 256
+		if sized[2] != "" {
+			bits, _ = strconv.Atoi(sized[2])
+		}
+		if bits == 0 || bits > 256 || bits%8 != 0 {
+			return eip712FieldType{}, fmt.Errorf("invalid integer size in EIP-712 type %q", typ)
+		}
+		fieldType.code, fieldType.size = eip712TypeUint, bits/8
+		if sized[1] == "int" {
+			fieldType.code = eip712TypeInt
+		}
+	default:
+		size, _ := strconv.Atoi(sized[2])
+		if size == 0 || size > 32 {
+			return eip712FieldType{}, fmt.Errorf("invalid bytes size in EIP-712 type %q", typ)
+		}
+		fieldType.code, fieldType.size = eip712TypeFixedBytes, size
+	}
+
+	return fieldType, nil
+}
+
+// encodeDefinition encodes the definition of a struct field with the given name.
+//
+//	Description                           | Length
+//	--------------------------------------+----------
+//	Type descriptor                       | 1 byte
+//	Type name length (custom types only)  | 1 byte
+//	Type name (custom types only)         | variable
+//	Type size (sized types only)          | 1 byte
+//	Array level count (arrays only)       | 1 byte
+//	Array level type, and size if fixed   | 1 or 2 bytes each
+//	Key name length                       | 1 byte
+//	Key name                              | variable
+func (t eip712FieldType) encodeDefinition(key string) ([]byte, error) {
+	if len(t.name) > 0xff || len(key) > 0xff || len(t.levels) > 0xff {
+		return nil, fmt.Errorf("EIP-712 field %q is too large", key)
+	}
+
+	descriptor := t.code
+	if len(t.levels) > 0 {
+		descriptor |= eip712TypeArrayFlag
+	}
+	if t.size > 0 {
+		descriptor |= eip712TypeSizeFlag
+	}
+
+	data := []byte{descriptor}
+	if t.code == eip712TypeCustom {
+		data = append(data, byte(len(t.name)))
+		data = append(data, t.name...)
+	}
+	if t.size > 0 {
+		data = append(data, byte(t.size))
+	}
+	if len(t.levels) > 0 {
+		data = append(data, byte(len(t.levels)))
+		for _, size := range t.levels {
+			if size < 0 {
+				data = append(data, eip712ArrayDynamic)
+			} else {
+				data = append(data, eip712ArrayFixed, byte(size))
+			}
+		}
+	}
+	data = append(data, byte(len(key)))
+	data = append(data, key...)
+
+	return data, nil
+}
+
+// encodeValue encodes a primitive field value as expected by the Ledger.
+func (t eip712FieldType) encodeValue(value interface{}) ([]byte, error) {
+	switch t.code {
+	case eip712TypeInt, eip712TypeUint:
+		n, err := EIP712Integer(value)
+		if err != nil {
+			return nil, err
+		}
+		if t.code == eip712TypeUint {
+			if n.Sign() < 0 || n.BitLen() > 8*t.size {
+				return nil, fmt.Errorf("value %s overflows %s", n, t.name)
+			}
+			if n.Sign() == 0 {
+				return []byte{0}, nil
+			}
+			return n.Bytes(), nil
+		}
+		// Signed integers are sent in two's complement on their full size
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(8*t.size-1))
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("value %s overflows %s", n, t.name)
+		}
+		return math.U256Bytes(new(big.Int).Set(n))[32-t.size:], nil
+
+	case eip712TypeAddress:
+		switch v := value.(type) {
+		case common.Address:
+			return v.Bytes(), nil
+		case string:
+			if !common.IsHexAddress(v) {
+				return nil, fmt.Errorf("invalid address %q", v)
+			}
+			return common.HexToAddress(v).Bytes(), nil
+		}
+
+	case eip712TypeBool:
+		if v, ok := value.(bool); ok {
+			if v {
+				return []byte{1}, nil
+			}
+			return []byte{0}, nil
+		}
+
+	case eip712TypeString:
+		if v, ok := value.(string); ok {
+			return []byte(v), nil
+		}
+
+	case eip712TypeFixedBytes, eip712TypeBytes:
+		var bz []byte
+		switch v := value.(type) {
+		case []byte:
+			bz = v
+		case hexutil.Bytes:
+			bz = v
+		case string:
+			decoded, err := hexutil.Decode(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid bytes %q: %w", v, err)
+			}
+			bz = decoded
+		default:
+			return nil, fmt.Errorf("invalid value of type %T for %s", value, t.name)
+		}
+		if t.code == eip712TypeFixedBytes && len(bz) > t.size {
+			return nil, fmt.Errorf("value of %d bytes overflows %s", len(bz), t.name)
+		}
+		return bz, nil
+	}
+
+	return nil, fmt.Errorf("invalid value of type %T for %s", value, t.name)
+}
+
+// EIP712Integer converts the value of an integer field of the typed data into a big
+// integer, accepting the representations found in decoded typed data: decimal or
+// hex strings (including negative decimals), JSON numbers and Go integers.
+func EIP712Integer(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v != nil {
+			return v, nil
+		}
+	case *math.HexOrDecimal256:
+		if v != nil {
+			return (*big.Int)(v), nil
+		}
+	case string:
+		if n, ok := math.ParseBig256(v); ok {
+			return n, nil
+		}
+		// Negative values are not accepted by ParseBig256
+		if n, ok := new(big.Int).SetString(v, 10); ok {
+			return n, nil
+		}
+	case json.Number:
+		if n, ok := new(big.Int).SetString(v.String(), 10); ok {
+			return n, nil
+		}
+	case float64:
+		if n, accuracy := big.NewFloat(v).Int(nil); accuracy == big.Exact {
+			return n, nil
+		}
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	}
+
+	return nil, fmt.Errorf("invalid integer value %v", value)
+}
+
+// ledgerSignTypedMessageFull streams the whole typed data to the Ledger wallet, so
+// that it displays the fields of the message, and waits for the user to confirm or
+// deny signing it.
+//
+// The struct definitions are sent first, one request per struct name and field:
+//
+//	CLA | INS | P1 | P2                         | Lc       | Le
+//	----+-----+----+----------------------------+----------+---
+//	 E0 | 1A  | 00 | 00: struct name            | variable | 00
+//	               | FF: struct field definition
+//
+// Followed by the values of the domain and then of the message, starting with the
+// name of the struct, and then one request per array size and field value, in the
+// order of the definitions (recursively for nested structs):
+//
+//	CLA | INS | P1                | P2                 | Lc       | Le
+//	----+-----+-------------------+--------------------+----------+---
+//	 E0 | 1C  | 00: complete data | 00: root struct    | variable | 00
+//	          | 01: partial data  | 0F: array size
+//	                              | FF: field value
+//
+// Field values are prefixed with their length (2 bytes, big endian) and split into
+// chunks of 255 bytes. Finally, the signature is requested with the derivation path
+// as in ledgerSignTypedMessage, but using the full implementation (P2 = 01).
+func (w *ledgerDriver) ledgerSignTypedMessageFull(derivationPath gethaccounts.DerivationPath, typedData apitypes.TypedData) ([]byte, error) {
+	// Send the definitions of all the structs
+	names := make([]string, 0, len(typedData.Types))
+	for name := range typedData.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := w.ledgerSendEIP712StructDefinition(name, typedData.Types); err != nil {
+			return nil, err
+		}
+	}
+
+	// Send the values of the domain and the message
+	if err := w.ledgerSendEIP712StructImplementation("EIP712Domain", typedData.Domain.Map(), typedData.Types); err != nil {
+		return nil, err
+	}
+	if err := w.ledgerSendEIP712StructImplementation(typedData.PrimaryType, typedData.Message, typedData.Types); err != nil {
+		return nil, err
+	}
+
+	// Flatten the derivation path into the Ledger request
+	path := make([]byte, 1+4*len(derivationPath))
+	path[0] = byte(len(derivationPath))
+	for i, component := range derivationPath {
+		binary.BigEndian.PutUint32(path[1+4*i:], component)
+	}
+
+	reply, err := w.ledgerExchange(ledgerOpSignTypedMessage, ledgerP1InitTypedMessageData, ledgerP2FullTypedMessage, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract the Ethereum signature and do a sanity validation
+	if len(reply) != crypto.SignatureLength {
+		return nil, fmt.Errorf("%w: reply lacks signature", ErrShortResponse)
+	}
+
+	var signature []byte
+	signature = append(signature, reply[1:]...)
+	signature = append(signature, reply[0])
+
+	return signature, nil
+}
+
+// ledgerSendEIP712StructDefinition sends the name and the field definitions of the
+// given struct to the Ledger.
+func (w *ledgerDriver) ledgerSendEIP712StructDefinition(name string, types apitypes.Types) error {
+	if len(name) > 0xff {
+		return fmt.Errorf("EIP-712 struct name %q is too long", name)
+	}
+	if _, err := w.ledgerExchange(ledgerOpEIP712StructDef, 0, ledgerP2EIP712StructName, []byte(name)); err != nil {
+		return err
+	}
+
+	for _, field := range types[name] {
+		fieldType, err := parseEIP712FieldType(field.Type, types)
+		if err != nil {
+			return err
+		}
+		definition, err := fieldType.encodeDefinition(field.Name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.ledgerExchange(ledgerOpEIP712StructDef, 0, ledgerP2EIP712Field, definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ledgerSendEIP712StructImplementation sends the name of the root struct followed
+// by its field values to the Ledger.
+func (w *ledgerDriver) ledgerSendEIP712StructImplementation(name string, data apitypes.TypedDataMessage, types apitypes.Types) error {
+	if len(name) > 0xff {
+		return fmt.Errorf("EIP-712 struct name %q is too long", name)
+	}
+	if _, ok := types[name]; !ok {
+		return fmt.Errorf("unknown EIP-712 struct %q", name)
+	}
+	if _, err := w.ledgerExchange(ledgerOpEIP712StructImpl, ledgerP1CompleteEIP712Field, ledgerP2EIP712StructName, []byte(name)); err != nil {
+		return err
+	}
+	return w.ledgerSendEIP712Fields(name, data, types)
+}
+
+// ledgerSendEIP712Fields sends the field values of the given struct to the Ledger.
+func (w *ledgerDriver) ledgerSendEIP712Fields(name string, data map[string]interface{}, types apitypes.Types) error {
+	for _, field := range types[name] {
+		value, ok := data[field.Name]
+		if !ok {
+			return fmt.Errorf("missing value of EIP-712 field %s.%s", name, field.Name)
+		}
+		fieldType, err := parseEIP712FieldType(field.Type, types)
+		if err != nil {
+			return err
+		}
+		if err := w.ledgerSendEIP712Value(fieldType, fieldType.levels, value, types); err != nil {
+			return fmt.Errorf("invalid EIP-712 field %s.%s: %w", name, field.Name, err)
+		}
+	}
+	return nil
+}
+
+// ledgerSendEIP712Value sends a field value to the Ledger, preceded by the size of
+// each of its remaining array levels.
+func (w *ledgerDriver) ledgerSendEIP712Value(fieldType eip712FieldType, levels []int, value interface{}, types apitypes.Types) error {
+	if len(levels) > 0 {
+		array := reflect.ValueOf(value)
+		if array.Kind() != reflect.Slice && array.Kind() != reflect.Array {
+			return fmt.Errorf("invalid array value of type %T", value)
+		}
+		if array.Len() > 0xff || levels[0] >= 0 && array.Len() != levels[0] {
+			return fmt.Errorf("invalid array size %d", array.Len())
+		}
+		if _, err := w.ledgerExchange(ledgerOpEIP712StructImpl, ledgerP1CompleteEIP712Field, ledgerP2EIP712Array, []byte{byte(array.Len())}); err != nil {
+			return err
+		}
+		for i := 0; i < array.Len(); i++ {
+			if err := w.ledgerSendEIP712Value(fieldType, levels[1:], array.Index(i).Interface(), types); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if fieldType.code == eip712TypeCustom {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			if message, isMessage := value.(apitypes.TypedDataMessage); isMessage {
+				data, ok = message, true
+			}
+		}
+		if !ok {
+			return fmt.Errorf("invalid %s value of type %T", fieldType.name, value)
+		}
+		return w.ledgerSendEIP712Fields(fieldType.name, data, types)
+	}
+
+	encoded, err := fieldType.encodeValue(value)
+	if err != nil {
+		return err
+	}
+	if len(encoded) > 0xffff {
+		return fmt.Errorf("value of %d bytes is too large", len(encoded))
+	}
+
+	// Prefix the value with its length and stream it in chunks
+	payload := binary.BigEndian.AppendUint16(nil, uint16(len(encoded)))
+	payload = append(payload, encoded...)
+
+	for len(payload) > 0 {
+		chunk, p1 := payload, ledgerP1CompleteEIP712Field
+		if len(chunk) > eip712ChunkSize {
+			chunk, p1 = chunk[:eip712ChunkSize], ledgerP1PartialEIP712Field
+		}
+		if _, err := w.ledgerExchange(ledgerOpEIP712StructImpl, p1, ledgerP2EIP712Field, chunk); err != nil {
+			return err
+		}
+		payload = payload[len(chunk):]
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ package usbwallet
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -27,6 +28,7 @@ import (
 	"runtime"
 
 	gethaccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/evmos/evmos-ledger-go/accounts"
 	usb "github.com/zondax/hid"
 )
@@ -38,6 +40,10 @@ const (
 	// onLinux is a boolean value to check if the operating system is Linux-based.
 	onLinux = runtime.GOOS == "linux"
 
+	// refreshCycle is the default maximum time between wallet refreshes (if USB
+	// hotplug notifications don't work).
+	refreshCycle = time.Second
+
 	// refreshThrottling is the minimum time between wallet refreshes to avoid USB
 	// trashing.
 	refreshThrottling = 500 * time.Millisecond
@@ -53,9 +59,17 @@ type Hub struct {
 	usageID    uint16        // USB usage page identifier used for macOS device discovery
 	endpointID int           // USB endpoint identifier used for non-macOS device discovery
 	makeDriver func() driver // Factory method to construct a vendor specific driver
+	transport  Transport     // Transport used to discover and connect to the devices
+
+	commandTimeout atomic.Int64                 // Timeout of each device command in nanoseconds, unbounded if zero
+	recorder       atomic.Pointer[APDURecorder] // Recorder of the APDUs exchanged with the devices, if any
 
-	refreshed time.Time         // Time instance when the list of wallets was last refreshed
-	wallets   []accounts.Wallet // List of USB wallet devices currently tracking
+	refreshed   time.Time               // Time instance when the list of wallets was last refreshed
+	refreshRate time.Duration           // Time between wallet refreshes while subscribers are listening
+	wallets     []accounts.Wallet       // List of USB wallet devices currently tracking
+	updateFeed  event.Feed              // Event feed to notify wallet additions/removals
+	updateScope event.SubscriptionScope // Subscription scope tracking current live listeners
+	updating    bool                    // Whether the event notification loop is running
 
 	quit chan chan error
 
@@ -67,8 +81,21 @@ type Hub struct {
 	enumFails uint32     // Number of times enumeration has failed
 }
 
-// NewLedgerHub creates a new hardware wallet manager for Ledger devices.
+// NewLedgerHub creates a new hardware wallet manager for Ledger devices, which
+// reaches them directly as USB HID devices.
 func NewLedgerHub() (*Hub, error) {
+	if !usb.Supported() {
+		return nil, errors.New("unsupported platform")
+	}
+	return NewLedgerHubWithTransport(HIDTransport{})
+}
+
+// NewLedgerHubWithTransport creates a new hardware wallet manager for Ledger
+// devices, which discovers and connects to them through the given transport.
+func NewLedgerHubWithTransport(transport Transport) (*Hub, error) {
+	if transport == nil {
+		return nil, errors.New("no transport provided")
+	}
 	return newHub(LedgerScheme, 0x2c97, []uint16{
 		// Device definitions taken from
 		// https://github.com/LedgerHQ/ledger-live/blob/38012bc8899e0f07149ea9cfe7e64b2c146bc92b/libs/ledgerjs/packages/devices/src/index.ts
@@ -91,14 +118,11 @@ func NewLedgerHub() (*Hub, error) {
 		0x4011, /* HID + WebUSB Ledger Nano X */
 		0x5011, /* HID + WebUSB Ledger Nano S Plus */
 		0x6011, /* HID + WebUSB Ledger Nano FTS */
-	}, 0xffa0, 0, newLedgerDriver)
+	}, 0xffa0, 0, newLedgerDriver, transport)
 }
 
 // newHub creates a new hardware wallet manager for generic USB devices.
-func newHub(scheme string, vendorID uint16, productIDs []uint16, usageID uint16, endpointID int, makeDriver func() driver) (*Hub, error) {
-	if !usb.Supported() {
-		return nil, errors.New("unsupported platform")
-	}
+func newHub(scheme string, vendorID uint16, productIDs []uint16, usageID uint16, endpointID int, makeDriver func() driver, transport Transport) (*Hub, error) {
 	hub := &Hub{
 		scheme:     scheme,
 		vendorID:   vendorID,
@@ -106,8 +130,10 @@ func newHub(scheme string, vendorID uint16, productIDs []uint16, usageID uint16,
 		usageID:    usageID,
 		endpointID: endpointID,
 		makeDriver: makeDriver,
+		transport:  transport,
 		quit:       make(chan chan error),
 	}
+	hub.refreshRate = refreshCycle
 	hub.refreshWallets()
 	return hub, nil
 }
@@ -126,21 +152,85 @@ func (hub *Hub) Wallets() []accounts.Wallet {
 	return cpy
 }
 
+// SetRefreshInterval sets the time between the scans of the USB devices performed
+// while subscribers are listening for wallet events, which bounds how quickly
+// devices being plugged in or unplugged are detected. Shorter intervals detect
+// changes faster at the cost of more USB traffic. A non-positive interval restores
+// the default of one second.
+func (hub *Hub) SetRefreshInterval(interval time.Duration) {
+	if interval <= 0 {
+		interval = refreshCycle
+	}
+
+	hub.stateLock.Lock()
+	defer hub.stateLock.Unlock()
+
+	hub.refreshRate = interval
+}
+
+// SetCommandTimeout bounds the time spent on each APDU exchanged with the devices,
+// across all of its HID reports, after which the request (e.g. a derivation or a
+// signing request) fails with ErrCommandTimeout and the connection to the device is
+// abandoned. The connection is only closed once no read or write is pending on it,
+// since closing a HID handle under a blocked read frees the handle while in use.
+// Note that signing commands wait for the user to confirm them on the device, so
+// the timeout must leave enough time for it. The timeout applies to the wallets
+// opened afterwards. A non-positive timeout, the default, disables it.
+func (hub *Hub) SetCommandTimeout(timeout time.Duration) {
+	if timeout < 0 {
+		timeout = 0
+	}
+	hub.commandTimeout.Store(int64(timeout))
+}
+
+// SetAPDURecorder records the APDU command/response pairs exchanged with the devices
+// with the given recorder, e.g. to attach a transcript to a bug report. The recorder
+// applies to the wallets opened afterwards. A nil recorder, the default, disables
+// recording.
+func (hub *Hub) SetAPDURecorder(recorder *APDURecorder) {
+	hub.recorder.Store(recorder)
+}
+
+// ErrRefreshPending is returned by RefreshDevices when the USB devices cannot be
+// scanned because a request is awaiting confirmation on a device.
+var ErrRefreshPending = errors.New("usbwallet: cannot scan devices while a confirmation is pending")
+
+// RefreshDevices immediately scans the USB devices attached to the machine and
+// updates the list of wallets, bypassing the throttling of the periodic refresh.
+// Unlike the periodic refresh, wallets are dropped even if no device remains
+// attached. Subscribers are notified of the wallets that arrived or were dropped.
+func (hub *Hub) RefreshDevices() error {
+	return hub.refresh(true)
+}
+
 // refreshWallets scans the USB devices attached to the machine and updates the
 // list of wallets based on the found devices.
 func (hub *Hub) refreshWallets() {
-	// Don't scan the USB like crazy it the user fetches wallets in a loop
-	hub.stateLock.RLock()
-	elapsed := time.Since(hub.refreshed)
-	hub.stateLock.RUnlock()
+	_ = hub.refresh(false)
+}
 
-	if elapsed < refreshThrottling {
-		return
-	}
+// refresh implements refreshWallets. If force is set, the scan is not throttled
+// and an error is returned if it cannot be performed.
+func (hub *Hub) refresh(force bool) error {
+	if !force {
+		// Don't scan the USB like crazy it the user fetches wallets in a loop
+		hub.stateLock.RLock()
+		elapsed := time.Since(hub.refreshed)
+		throttling := refreshThrottling
+		if hub.refreshRate < throttling {
+			// Don't throttle the updater below the requested refresh interval
+			throttling = hub.refreshRate
+		}
+		hub.stateLock.RUnlock()
+
+		if elapsed < throttling {
+			return nil
+		}
 
-	// If USB enumeration is continually failing, don't keep trying indefinitely
-	if atomic.LoadUint32(&hub.enumFails) > 2 {
-		return
+		// If USB enumeration is continually failing, don't keep trying indefinitely
+		if atomic.LoadUint32(&hub.enumFails) > 2 {
+			return nil
+		}
 	}
 
 	// Retrieve the current list of USB wallet devices
@@ -156,16 +246,32 @@ func (hub *Hub) refreshWallets() {
 		hub.commsLock.Lock()
 		if hub.commsPend > 0 { // A confirmation is pending, don't refresh
 			hub.commsLock.Unlock()
-			return
+			if force {
+				return ErrRefreshPending
+			}
+			return nil
 		}
 	}
-	infos := usb.Enumerate(hub.vendorID, 0)
-	if infos == nil {
+	infos, err := hub.transport.Enumerate(hub.vendorID)
+	if err != nil {
 		if onLinux {
 			// See rationale before the enumeration why this is needed and only on Linux.
 			hub.commsLock.Unlock()
 		}
-		return
+		atomic.AddUint32(&hub.enumFails, 1)
+		if force {
+			return fmt.Errorf("failed to enumerate devices: %w", err)
+		}
+		return nil
+	}
+	// HID enumeration yields no infos both on failure and if no device is attached,
+	// so only a forced refresh assumes the latter
+	if infos == nil && !force {
+		if onLinux {
+			// See rationale before the enumeration why this is needed and only on Linux.
+			hub.commsLock.Unlock()
+		}
+		return nil
 	}
 	atomic.StoreUint32(&hub.enumFails, 0)
 
@@ -187,7 +293,10 @@ func (hub *Hub) refreshWallets() {
 	// Transform the current list of wallets into the new one
 	hub.stateLock.Lock()
 
-	wallets := make([]accounts.Wallet, 0, len(devices))
+	var (
+		wallets = make([]accounts.Wallet, 0, len(devices))
+		events  []accounts.WalletEvent
+	)
 
 	for _, device := range devices {
 		url := gethaccounts.URL{
@@ -203,6 +312,7 @@ func (hub *Hub) refreshWallets() {
 				break
 			}
 			// Drop the stale and failed devices
+			events = append(events, accounts.WalletEvent{Wallet: hub.wallets[0], Kind: accounts.WalletDropped})
 			hub.wallets = hub.wallets[1:]
 		}
 
@@ -215,6 +325,7 @@ func (hub *Hub) refreshWallets() {
 				info:   device,
 			}
 
+			events = append(events, accounts.WalletEvent{Wallet: wallet, Kind: accounts.WalletArrived})
 			wallets = append(wallets, wallet)
 			continue
 		}
@@ -226,7 +337,61 @@ func (hub *Hub) refreshWallets() {
 		}
 	}
 
+	// Drop any leftover wallets and set the new batch
+	for _, wallet := range hub.wallets {
+		events = append(events, accounts.WalletEvent{Wallet: wallet, Kind: accounts.WalletDropped})
+	}
 	hub.refreshed = time.Now().UTC()
 	hub.wallets = wallets
 	hub.stateLock.Unlock()
+
+	// Fire all wallet events and return
+	for _, event := range events {
+		hub.updateFeed.Send(event)
+	}
+	return nil
+}
+
+// Subscribe implements accounts.Backend, creating an async subscription to
+// receive notifications on the addition or removal of USB wallets.
+func (hub *Hub) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	// We need the mutex to reliably start/stop the update loop
+	hub.stateLock.Lock()
+	defer hub.stateLock.Unlock()
+
+	// Subscribe the caller and track the subscriber count
+	sub := hub.updateScope.Track(hub.updateFeed.Subscribe(sink))
+
+	// Subscribers require an active notification loop, start it
+	if !hub.updating {
+		hub.updating = true
+		go hub.updater()
+	}
+	return sub
+}
+
+// updater is responsible for maintaining an up-to-date list of wallets managed
+// by the USB hub, and for firing wallet addition/removal events.
+func (hub *Hub) updater() {
+	for {
+		// TODO: Wait for a USB hotplug event (not supported yet) or a refresh timeout
+		// <-hub.changes
+		hub.stateLock.RLock()
+		interval := hub.refreshRate
+		hub.stateLock.RUnlock()
+
+		time.Sleep(interval)
+
+		// Run the wallet refresher
+		hub.refreshWallets()
+
+		// If all our subscribers left, stop the updater
+		hub.stateLock.Lock()
+		if hub.updateScope.Count() == 0 {
+			hub.updating = false
+			hub.stateLock.Unlock()
+			return
+		}
+		hub.stateLock.Unlock()
+	}
 }
@@ -27,12 +27,20 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 
 	gethaccounts "github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/evmos/evmos-ledger-go/accounts"
 )
 
+// ledgerClass is an enumeration encoding the supported Ledger instruction classes.
+type ledgerClass byte
+
 // ledgerOpcode is an enumeration encoding the supported Ledger opcodes.
 type ledgerOpcode byte
 
@@ -45,15 +53,45 @@ type ledgerParam1 byte
 type ledgerParam2 byte
 
 const (
-	ledgerOpRetrieveAddress  ledgerOpcode = 0x02 // Returns the public key and Ethereum address for a given BIP 32 path
-	ledgerOpGetConfiguration ledgerOpcode = 0x06 // Returns specific wallet application configuration
-	ledgerOpSignTypedMessage ledgerOpcode = 0x0c // Signs an Ethereum message following the EIP 712 specification
+	ledgerClassEthereum ledgerClass = 0xe0 // Instructions handled by the Ethereum app
+	ledgerClassBolos    ledgerClass = 0xb0 // Instructions handled by the device OS, independently of the running app
+
+	ledgerOpGetAppAndVersion ledgerOpcode = 0x01 // Returns the name and version of the running app (BOLOS class)
+
+	ledgerOpRetrieveAddress     ledgerOpcode = 0x02 // Returns the public key and Ethereum address for a given BIP 32 path
+	ledgerOpSignTransaction     ledgerOpcode = 0x04 // Signs an Ethereum transaction after confirming the transfer amount
+	ledgerOpGetConfiguration    ledgerOpcode = 0x06 // Returns specific wallet application configuration
+	ledgerOpSignPersonalMessage ledgerOpcode = 0x08 // Signs an Ethereum message following the EIP 191 specification
+	ledgerOpProvideTokenInfo    ledgerOpcode = 0x0a // Provides signed ERC-20 token information for display
+	ledgerOpSignTypedMessage    ledgerOpcode = 0x0c // Signs an Ethereum message following the EIP 712 specification
+	ledgerOpEIP712StructDef     ledgerOpcode = 0x1a // Sends an EIP 712 struct definition (full implementation)
+	ledgerOpEIP712StructImpl    ledgerOpcode = 0x1c // Sends an EIP 712 struct implementation (full implementation)
+	ledgerOpOpenApp             ledgerOpcode = 0xd8 // Launches an app from the dashboard (BOLOS)
+	ledgerOpListAppsFirst       ledgerOpcode = 0xde // Returns the first installed apps from the dashboard (BOLOS)
+	ledgerOpListAppsNext        ledgerOpcode = 0xdf // Returns the next installed apps from the dashboard (BOLOS)
 
 	ledgerP1DirectlyFetchAddress    ledgerParam1 = 0x00 // Return address directly from the wallet
+	ledgerP1ConfirmFetchAddress     ledgerParam1 = 0x01 // Display address and wait for user confirmation before returning
+	ledgerP1InitTransactionData     ledgerParam1 = 0x00 // First transaction data block for signing
+	ledgerP1ContTransactionData     ledgerParam1 = 0x80 // Subsequent transaction data block for signing
+	ledgerP1InitPersonalMessageData ledgerParam1 = 0x00 // First chunk of Personal Message data
+	ledgerP1ContPersonalMessageData ledgerParam1 = 0x80 // Subsequent chunk of Personal Message data
 	ledgerP1InitTypedMessageData    ledgerParam1 = 0x00 // First chunk of Typed Message data
+	ledgerP1CompleteEIP712Field     ledgerParam1 = 0x00 // Last chunk of an EIP 712 field value
+	ledgerP1PartialEIP712Field      ledgerParam1 = 0x01 // Chunk of an EIP 712 field value followed by others
 	ledgerP2DiscardAddressChainCode ledgerParam2 = 0x00 // Do not return the chain code along with the address
+	ledgerP2ReturnAddressChainCode  ledgerParam2 = 0x01 // Return the chain code along with the address
+	ledgerP2HashedTypedMessage      ledgerParam2 = 0x00 // Sign the EIP 712 message from its domain and message hashes
+	ledgerP2FullTypedMessage        ledgerParam2 = 0x01 // Sign the EIP 712 message streamed beforehand
+	ledgerP2EIP712StructName        ledgerParam2 = 0x00 // EIP 712 struct definition name, or root struct implementation
+	ledgerP2EIP712Array             ledgerParam2 = 0x0f // EIP 712 array implementation size
+	ledgerP2EIP712Field             ledgerParam2 = 0xff // EIP 712 struct field definition or implementation
 )
 
+// ledgerFlagBlindSigning is set in the flags of the Ethereum app configuration if
+// the user enabled blind signing (i.e. signing arbitrary data) in the app settings.
+const ledgerFlagBlindSigning byte = 0x01
+
 // errLedgerReplyInvalidHeader is the error message returned by a Ledger data exchange
 // if the device replies with a mismatching header. This usually means the device
 // is in browser mode.
@@ -63,12 +101,95 @@ var errLedgerReplyInvalidHeader = errors.New("ledger: invalid reply header")
 // when a response does arrive, but it does not contain the expected data.
 var errLedgerInvalidVersionReply = errors.New("ledger: invalid version reply")
 
+// Status words returned by the Ledger in the last two bytes of every reply.
+const (
+	ledgerSWSuccess                  uint16 = 0x9000 // Request processed successfully
+	ledgerSWConditionsNotMet         uint16 = 0x6985 // Request denied by the user
+	ledgerSWSecurityNotSatisfied     uint16 = 0x6982 // Device locked (older firmware)
+	ledgerSWDeviceLocked             uint16 = 0x5515 // Device locked
+	ledgerSWInsNotSupported          uint16 = 0x6d00 // Instruction not supported, the Ethereum app is not open
+	ledgerSWAppNotOpen               uint16 = 0x6511 // No app running, the device is on the dashboard
+	ledgerSWDashboardUnknownAPDU     uint16 = 0x6d02 // Instruction not supported by the dashboard (newer firmware)
+	ledgerSWDashboardClaNotSupported uint16 = 0x6e01 // Class not supported by the dashboard (newer firmware)
+	ledgerSWClaNotSupported          uint16 = 0x6e00 // Class not supported, another app is open
+	ledgerSWAppNotInstalled          uint16 = 0x6807 // Requested app is not installed on the device
+	ledgerSWInvalidData              uint16 = 0x6a80 // Request data rejected, e.g. blind signing is disabled
+)
+
+var (
+	// ErrUserRejected is returned when the user declines a request on the device.
+	ErrUserRejected = errors.New("ledger: request rejected by the user")
+
+	// ErrDeviceLocked is returned when the device is locked and must be unlocked with the PIN.
+	ErrDeviceLocked = errors.New("ledger: device is locked, please unlock it and retry")
+
+	// ErrAppNotOpen is returned when no app is running on the device.
+	ErrAppNotOpen = errors.New("ledger: Ethereum app is not open, please open it and retry")
+
+	// ErrWrongApp is returned when an app other than the Ethereum app is running on the device.
+	ErrWrongApp = errors.New("ledger: another app is open, please open the Ethereum app and retry")
+
+	// ErrAppNotInstalled is returned when the app requested to be opened is not installed on the device.
+	ErrAppNotInstalled = errors.New("ledger: app is not installed")
+
+	// ErrInvalidData is returned when the app rejects the data of a request, e.g. a
+	// blind signing request while blind signing is disabled in the app settings.
+	ErrInvalidData = errors.New("ledger: invalid request data")
+)
+
+// APDUError is returned when the Ledger replies to a request with a status word
+// other than success. Known status words unwrap to one of the sentinel errors
+// (e.g. ErrUserRejected), so callers can branch on them using errors.Is.
+type APDUError struct {
+	StatusWord uint16
+}
+
+// Error implements the error interface.
+func (e *APDUError) Error() string {
+	if err := e.Unwrap(); err != nil {
+		return fmt.Sprintf("%s (status word 0x%04x)", err, e.StatusWord)
+	}
+	return fmt.Sprintf("ledger: unexpected status word 0x%04x", e.StatusWord)
+}
+
+// Unwrap returns the sentinel error matching the status word, if any.
+func (e *APDUError) Unwrap() error {
+	switch e.StatusWord {
+	case ledgerSWConditionsNotMet:
+		return ErrUserRejected
+	case ledgerSWSecurityNotSatisfied, ledgerSWDeviceLocked:
+		return ErrDeviceLocked
+	case ledgerSWInsNotSupported, ledgerSWAppNotOpen, ledgerSWDashboardUnknownAPDU, ledgerSWDashboardClaNotSupported:
+		return ErrAppNotOpen
+	case ledgerSWClaNotSupported:
+		return ErrWrongApp
+	case ledgerSWAppNotInstalled:
+		return ErrAppNotInstalled
+	case ledgerSWInvalidData:
+		return ErrInvalidData
+	default:
+		return nil
+	}
+}
+
+// ErrShortResponse is returned (wrapped) when a reply of the Ledger is truncated and
+// lacks some of the expected entries, e.g. because the device was unplugged while
+// it was being transferred.
+var ErrShortResponse = errors.New("ledger: short response")
+
+// ErrDeviceIO is returned (wrapped) by the Ledger data exchange when reading from or
+// writing to the USB device fails. Such failures are usually transient (e.g. flaky
+// cables or hubs) and the request can be retried.
+var ErrDeviceIO = errors.New("ledger: device communication failure")
+
 // ledgerDriver implements the communication with a Ledger hardware wallet.
 type ledgerDriver struct {
 	device  io.ReadWriter // USB device connection to communicate through
 	version [3]byte       // Current version of the Ledger firmware (zero if app is offline)
 	browser bool          // Flag whether the Ledger is in browser mode (reply channel mismatch)
 	failure error         // Any failure that would make the device unusable
+
+	recorder *APDURecorder // Recorder of the APDUs exchanged with the device, if any
 }
 
 // newLedgerDriver creates a new instance of a Ledger USB protocol driver.
@@ -76,6 +197,12 @@ func newLedgerDriver() driver {
 	return &ledgerDriver{}
 }
 
+// setRecorder implements usbwallet.recordingDriver, recording the APDUs exchanged
+// from now on with the given recorder, or none if nil.
+func (w *ledgerDriver) setRecorder(recorder *APDURecorder) {
+	w.recorder = recorder
+}
+
 // Status implements usbwallet.driver, returning various states the Ledger can
 // currently be in.
 func (w *ledgerDriver) Status() (string, error) {
@@ -104,7 +231,7 @@ func (w *ledgerDriver) offline() bool {
 func (w *ledgerDriver) Open(device io.ReadWriter, _ string) error {
 	w.device, w.failure = device, nil
 
-	_, _, err := w.ledgerDerive(gethaccounts.DefaultBaseDerivationPath)
+	_, _, err := w.ledgerDerive(gethaccounts.DefaultBaseDerivationPath, false)
 	if err != nil {
 		// Ethereum app is not running or in browser mode, nothing more to do, return
 		if err == errLedgerReplyInvalidHeader {
@@ -129,10 +256,24 @@ func (w *ledgerDriver) Close() error {
 	return nil
 }
 
+// AppConfiguration implements usbwallet.driver, retrieving the configuration of the
+// Ethereum app running on the Ledger.
+func (w *ledgerDriver) AppConfiguration() (accounts.AppConfiguration, error) {
+	return w.ledgerConfiguration()
+}
+
+// RunningApp implements usbwallet.driver, retrieving the name and version of the app
+// currently running on the Ledger.
+func (w *ledgerDriver) RunningApp() (accounts.AppInfo, error) {
+	return w.ledgerAppAndVersion()
+}
+
 // Heartbeat implements usbwallet.driver, performing a sanity check against the
 // Ledger to see if it's still online.
 func (w *ledgerDriver) Heartbeat() error {
-	if _, err := w.ledgerVersion(); err != nil && err != errLedgerInvalidVersionReply {
+	// Replies with an error status word (e.g. app closed) still prove the device is online
+	var apduErr *APDUError
+	if _, err := w.ledgerVersion(); err != nil && err != errLedgerInvalidVersionReply && !errors.As(err, &apduErr) {
 		w.failure = err
 		return err
 	}
@@ -141,8 +282,35 @@ func (w *ledgerDriver) Heartbeat() error {
 
 // Derive implements usbwallet.driver, sending a derivation request to the Ledger
 // and returning the Ethereum address located on that derivation path.
-func (w *ledgerDriver) Derive(path gethaccounts.DerivationPath) (common.Address, *ecdsa.PublicKey, error) {
-	return w.ledgerDerive(path)
+func (w *ledgerDriver) Derive(path gethaccounts.DerivationPath, display bool) (common.Address, *ecdsa.PublicKey, error) {
+	return w.ledgerDerive(path, display)
+}
+
+// DeriveWithChainCode implements usbwallet.driver, sending a derivation request to
+// the Ledger and returning the Ethereum address located on that derivation path,
+// along with the BIP-32 chain code of the derived node.
+func (w *ledgerDriver) DeriveWithChainCode(path gethaccounts.DerivationPath) (common.Address, *ecdsa.PublicKey, []byte, error) {
+	return w.ledgerDeriveNode(path, false, true)
+}
+
+// SignTx implements usbwallet.driver, sending the transaction to the Ledger and
+// waiting for the user to confirm or deny it.
+//
+// Note, if the version of the Ethereum application running on the Ledger wallet is
+// too old to sign EIP-155 transactions, but such is requested nonetheless, an error
+// will be returned opposed to silently signing in Homestead mode.
+func (w *ledgerDriver) SignTx(path gethaccounts.DerivationPath, tx *types.Transaction, chainID *big.Int) (common.Address, *types.Transaction, error) {
+	// If the Ethereum app doesn't run, abort
+	if w.offline() {
+		return common.Address{}, nil, gethaccounts.ErrWalletClosed
+	}
+	// Ensure the wallet is capable of signing the given transaction
+	if chainID != nil && w.version[0] <= 1 && w.version[1] <= 0 && w.version[2] <= 2 {
+		//nolint:stylecheck // ST1005 requires error strings to be lowercase but Ledger as a brand name should start with a capital letter
+		return common.Address{}, nil, fmt.Errorf("Ledger v%d.%d.%d doesn't support signing this transaction, please update to v1.0.3 at least", w.version[0], w.version[1], w.version[2])
+	}
+	// All infos gathered and metadata checks out, request signing
+	return w.ledgerSign(path, tx, chainID)
 }
 
 // SignTypedMessage implements usbwallet.driver, sending the message to the Ledger and
@@ -163,10 +331,67 @@ func (w *ledgerDriver) SignTypedMessage(path gethaccounts.DerivationPath, domain
 	return w.ledgerSignTypedMessage(path, domainHash, messageHash)
 }
 
+// SignTypedMessageFull implements usbwallet.driver, streaming the whole typed data to
+// the Ledger, which displays its fields, and waiting for the user to sign or deny it.
+//
+// Note: this was introduced in the ledger 1.9.19 firmware; the Ledger rejects the
+// request on older versions.
+func (w *ledgerDriver) SignTypedMessageFull(path gethaccounts.DerivationPath, typedData apitypes.TypedData) ([]byte, error) {
+	// If the Ethereum app doesn't run, abort
+	if w.offline() {
+		return nil, gethaccounts.ErrWalletClosed
+	}
+	// All infos gathered and metadata checks out, request signing
+	return w.ledgerSignTypedMessageFull(path, typedData)
+}
+
+// ProvideTokenInfo implements usbwallet.driver, sending the signed ERC-20 token
+// descriptor to the Ledger.
+func (w *ledgerDriver) ProvideTokenInfo(token accounts.TokenInfo) error {
+	// If the Ethereum app doesn't run, abort
+	if w.offline() {
+		return gethaccounts.ErrWalletClosed
+	}
+	return w.ledgerProvideTokenInfo(token)
+}
+
+// SignPersonalMessage implements usbwallet.driver, sending the message to the Ledger
+// and waiting for the user to sign or deny it.
+func (w *ledgerDriver) SignPersonalMessage(path gethaccounts.DerivationPath, message []byte) ([]byte, error) {
+	// If the Ethereum app doesn't run, abort
+	if w.offline() {
+		return nil, gethaccounts.ErrWalletClosed
+	}
+	// All infos gathered and metadata checks out, request signing
+	return w.ledgerSignPersonalMessage(path, message)
+}
+
+// OpenApp implements usbwallet.driver, requesting the Ledger dashboard to launch
+// the app with the given name.
+func (w *ledgerDriver) OpenApp(name string) error {
+	return w.ledgerOpenApp(name)
+}
+
+// ListApps implements usbwallet.driver, requesting the Ledger dashboard to list the
+// installed apps.
+func (w *ledgerDriver) ListApps() ([]accounts.AppInfo, error) {
+	return w.ledgerListApps()
+}
+
 // ledgerVersion retrieves the current version of the Ethereum wallet app running
 // on the Ledger wallet.
+func (w *ledgerDriver) ledgerVersion() ([3]byte, error) {
+	config, err := w.ledgerConfiguration()
+	if err != nil {
+		return [3]byte{}, err
+	}
+	return config.Version, nil
+}
+
+// ledgerConfiguration retrieves the configuration of the Ethereum wallet app running
+// on the Ledger wallet.
 //
-// The version retrieval protocol is defined as follows:
+// The configuration retrieval protocol is defined as follows:
 //
 //	CLA | INS | P1 | P2 | Lc | Le
 //	----+-----+----+----+----+---
@@ -180,19 +405,148 @@ func (w *ledgerDriver) SignTypedMessage(path gethaccounts.DerivationPath, domain
 //	Application major version                          | 1 byte
 //	Application minor version                          | 1 byte
 //	Application patch version                          | 1 byte
-func (w *ledgerDriver) ledgerVersion() ([3]byte, error) {
+func (w *ledgerDriver) ledgerConfiguration() (accounts.AppConfiguration, error) {
 	// Send the request and wait for the response
 	reply, err := w.ledgerExchange(ledgerOpGetConfiguration, 0, 0, nil)
 	if err != nil {
-		return [3]byte{}, err
+		return accounts.AppConfiguration{}, err
 	}
 	if len(reply) != 4 {
-		return [3]byte{}, errLedgerInvalidVersionReply
+		return accounts.AppConfiguration{}, errLedgerInvalidVersionReply
+	}
+
+	config := accounts.AppConfiguration{
+		BlindSigning: reply[0]&ledgerFlagBlindSigning != 0,
+	}
+	copy(config.Version[:], reply[1:])
+	return config, nil
+}
+
+// ledgerAppAndVersion retrieves the name and version of the app currently running
+// on the Ledger wallet. Contrary to the other requests, this one is handled by the
+// device OS, so it succeeds whichever app is open (or on the dashboard).
+//
+// The app and version retrieval protocol is defined as follows:
+//
+//	CLA | INS | P1 | P2 | Lc | Le
+//	----+-----+----+----+----+---
+//	 B0 | 01  | 00 | 00 | 00 | variable
+//
+// With no input data, and the output data being:
+//
+//	Description                 | Length
+//	----------------------------+----------
+//	Format (always 01)          | 1 byte
+//	App name length             | 1 byte
+//	App name (ascii)            | variable
+//	App version length          | 1 byte
+//	App version (ascii)         | variable
+func (w *ledgerDriver) ledgerAppAndVersion() (accounts.AppInfo, error) {
+	// Send the request and wait for the response
+	reply, err := w.ledgerExchangeWithClass(ledgerClassBolos, ledgerOpGetAppAndVersion, 0, 0, nil)
+	if err != nil {
+		return accounts.AppInfo{}, err
+	}
+	if len(reply) < 1 || reply[0] != 0x01 {
+		return accounts.AppInfo{}, errors.New("ledger: invalid app and version reply")
+	}
+	reply = reply[1:]
+
+	// Extract the app name and version, which are both length-prefixed
+	fields := make([]string, 2)
+	for i := range fields {
+		// #nosec G701 -- gosec will raise a warning on this integer conversion for potential overflow
+		if len(reply) < 1 || len(reply) < 1+int(reply[0]) {
+			return accounts.AppInfo{}, errors.New("ledger: invalid app and version reply")
+		}
+		// #nosec G701 -- gosec will raise a warning on this integer conversion for potential overflow
+		length := int(reply[0])
+
+		fields[i] = string(reply[1 : 1+length])
+		reply = reply[1+length:]
+	}
+	return accounts.AppInfo{Name: fields[0], Version: fields[1]}, nil
+}
+
+// ledgerOpenApp requests the Ledger dashboard to launch the app with the given
+// name, which the user must confirm on the device. The request is handled by the
+// device OS and only succeeds while the dashboard is displayed.
+//
+// The open app protocol is defined as follows:
+//
+//	CLA | INS | P1 | P2 | Lc  | Le
+//	----+-----+----+----+-----+---
+//	 E0 | D8  | 00 | 00 | var | 00
+//
+// Where the input data is the name of the app (ascii, e.g. "Ethereum"), and there
+// is no output data.
+func (w *ledgerDriver) ledgerOpenApp(name string) error {
+	if len(name) == 0 || len(name) > 255 {
+		return fmt.Errorf("ledger: invalid app name length: %d", len(name))
+	}
+	_, err := w.ledgerExchange(ledgerOpOpenApp, 0, 0, []byte(name))
+	return err
+}
+
+// ledgerListApps requests the Ledger dashboard to list the installed apps, which are
+// returned in batches until the reply is empty. The request is handled by the device
+// OS and only succeeds while the dashboard is displayed.
+//
+// The list apps protocol is defined as follows:
+//
+//	CLA | INS | P1 | P2 | Lc | Le
+//	----+-----+----+----+----+---
+//	 E0 | DE  | 00 | 00 | 00 | variable (first batch)
+//	 E0 | DF  | 00 | 00 | 00 | variable (next batches)
+//
+// With no input data, and the output data being:
+//
+//	Description                 | Length
+//	----------------------------+----------
+//	Format (always 01)          | 1 byte
+//	First app entry length      | 1 byte
+//	First app flags             | 4 bytes
+//	First app code hash         | 32 bytes
+//	First app full hash         | 32 bytes
+//	First app name length       | 1 byte
+//	First app name (ascii)      | variable
+//	...                         | variable
+//
+// The versions of the apps are not part of the entries.
+func (w *ledgerDriver) ledgerListApps() ([]accounts.AppInfo, error) {
+	var apps []accounts.AppInfo
+	for opcode := ledgerOpListAppsFirst; ; opcode = ledgerOpListAppsNext {
+		reply, err := w.ledgerExchange(opcode, 0, 0, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(reply) == 0 {
+			return apps, nil
+		}
+		if reply[0] != 0x01 {
+			return nil, errors.New("ledger: invalid installed apps reply")
+		}
+		reply = reply[1:]
+
+		for len(reply) > 0 {
+			// #nosec G701 -- gosec will raise a warning on this integer conversion for potential overflow
+			if len(reply) < 1+4+32+32+1 || len(reply) < 1+int(reply[0]) {
+				return nil, fmt.Errorf("%w: reply lacks app entry", ErrShortResponse)
+			}
+			// #nosec G701 -- gosec will raise a warning on this integer conversion for potential overflow
+			entry := reply[1 : 1+int(reply[0])]
+			reply = reply[1+len(entry):]
+
+			// #nosec G701 -- gosec will raise a warning on this integer conversion for potential overflow
+			if len(entry) < 4+32+32+1 || len(entry) < 4+32+32+1+int(entry[68]) {
+				return nil, fmt.Errorf("%w: reply lacks app name", ErrShortResponse)
+			}
+			apps = append(apps, accounts.AppInfo{
+				Name: string(entry[69 : 69+int(entry[68])]),
+				Hash: append([]byte(nil), entry[36:68]...),
+			})
+		}
 	}
-	// Cache the version for future reference
-	var version [3]byte
-	copy(version[:], reply[1:])
-	return version, nil
 }
 
 // ledgerDerive retrieves the currently active Ethereum address from a Ledger
@@ -226,7 +580,14 @@ func (w *ledgerDriver) ledgerVersion() ([3]byte, error) {
 //	Ethereum address length | 1 byte
 //	Ethereum address        | 40 bytes hex ascii
 //	Chain code if requested | 32 bytes
-func (w *ledgerDriver) ledgerDerive(derivationPath gethaccounts.DerivationPath) (common.Address, *ecdsa.PublicKey, error) {
+func (w *ledgerDriver) ledgerDerive(derivationPath gethaccounts.DerivationPath, display bool) (common.Address, *ecdsa.PublicKey, error) {
+	address, publicKey, _, err := w.ledgerDeriveNode(derivationPath, display, false)
+	return address, publicKey, err
+}
+
+// ledgerDeriveNode implements ledgerDerive, additionally returning the chain code
+// of the derived node if requested.
+func (w *ledgerDriver) ledgerDeriveNode(derivationPath gethaccounts.DerivationPath, display, withChainCode bool) (common.Address, *ecdsa.PublicKey, []byte, error) {
 	// Flatten the derivation path into the Ledger request
 	path := make([]byte, 1+4*len(derivationPath))
 	path[0] = byte(len(derivationPath))
@@ -234,16 +595,26 @@ func (w *ledgerDriver) ledgerDerive(derivationPath gethaccounts.DerivationPath)
 		binary.BigEndian.PutUint32(path[1+4*i:], component)
 	}
 
+	p1 := ledgerP1DirectlyFetchAddress
+	if display {
+		p1 = ledgerP1ConfirmFetchAddress
+	}
+
+	p2 := ledgerP2DiscardAddressChainCode
+	if withChainCode {
+		p2 = ledgerP2ReturnAddressChainCode
+	}
+
 	// Send the request and wait for the response
-	reply, err := w.ledgerExchange(ledgerOpRetrieveAddress, ledgerP1DirectlyFetchAddress, ledgerP2DiscardAddressChainCode, path)
+	reply, err := w.ledgerExchange(ledgerOpRetrieveAddress, p1, p2, path)
 	if err != nil {
-		return common.Address{}, nil, err
+		return common.Address{}, nil, nil, err
 	}
 
 	// Verify public key was returned
 	// #nosec G701 -- gosec will raise a warning on this integer conversion for potential overflow
 	if len(reply) < 1 || len(reply) < 1+int(reply[0]) {
-		return common.Address{}, nil, errors.New("reply lacks public key entry")
+		return common.Address{}, nil, nil, fmt.Errorf("%w: reply lacks public key entry", ErrShortResponse)
 	}
 
 	// #nosec G701 -- gosec will raise a warning on this integer conversion for potential overflow
@@ -253,7 +624,7 @@ func (w *ledgerDriver) ledgerDerive(derivationPath gethaccounts.DerivationPath)
 
 	publicKey, err := crypto.UnmarshalPubkey(pubkeyBz)
 	if err != nil {
-		return common.Address{}, nil, fmt.Errorf("failed to unmarshal public key: %w", err)
+		return common.Address{}, nil, nil, fmt.Errorf("failed to unmarshal public key: %w", err)
 	}
 
 	// Discard pubkey after fetching
@@ -262,7 +633,7 @@ func (w *ledgerDriver) ledgerDerive(derivationPath gethaccounts.DerivationPath)
 	// Extract the Ethereum hex address string
 	// #nosec G701 -- gosec will raise a warning on this integer conversion for potential overflow
 	if len(reply) < 1 || len(reply) < 1+int(reply[0]) {
-		return common.Address{}, nil, errors.New("reply lacks address entry")
+		return common.Address{}, nil, nil, fmt.Errorf("%w: reply lacks address entry", ErrShortResponse)
 	}
 
 	// Reset first byte after discarding pubkey from response
@@ -270,19 +641,169 @@ func (w *ledgerDriver) ledgerDerive(derivationPath gethaccounts.DerivationPath)
 	replyFirstByteAsInt = int(reply[0])
 
 	hexStr := reply[1 : 1+replyFirstByteAsInt]
+	if len(hexStr) != 2*common.AddressLength {
+		return common.Address{}, nil, nil, fmt.Errorf("%w: reply lacks address entry", ErrShortResponse)
+	}
 
 	// Decode the hex string into an Ethereum address and return
 	var address common.Address
 	if _, err = hex.Decode(address[:], hexStr); err != nil {
-		return common.Address{}, nil, err
+		return common.Address{}, nil, nil, err
 	}
 
 	derivedAddr := crypto.PubkeyToAddress(*publicKey)
 	if derivedAddr != address {
-		return common.Address{}, nil, fmt.Errorf("address mismatch, expected %s, got %s", derivedAddr, address)
+		return common.Address{}, nil, nil, fmt.Errorf("address mismatch, expected %s, got %s", derivedAddr, address)
 	}
 
-	return address, publicKey, nil
+	if !withChainCode {
+		return address, publicKey, nil, nil
+	}
+
+	// Extract the chain code following the address
+	reply = reply[1+replyFirstByteAsInt:]
+	if len(reply) < 32 {
+		return common.Address{}, nil, nil, fmt.Errorf("%w: reply lacks chain code entry", ErrShortResponse)
+	}
+
+	return address, publicKey, reply[:32], nil
+}
+
+// ledgerSign sends the transaction to the Ledger wallet, and waits for the user to
+// confirm or deny the transaction.
+//
+// The transaction signing protocol is defined as follows:
+//
+//	CLA | INS | P1 | P2 | Lc  | Le
+//	----+-----+----+----+-----+---
+//	 E0 | 04  | 00: first transaction data block
+//	            80: subsequent transaction data block
+//	               | 00 | variable | variable
+//
+// Where the input for the first transaction block (first 255 bytes) is:
+//
+//	Description                                      | Length
+//	-------------------------------------------------+----------
+//	Number of BIP 32 derivations to perform (max 10) | 1 byte
+//	First derivation index (big endian)              | 4 bytes
+//	...                                              | 4 bytes
+//	Last derivation index (big endian)               | 4 bytes
+//	Transaction chunk                                | arbitrary
+//
+// And the input for subsequent transaction blocks (if input larger than 255 bytes) is:
+//
+//	Description           | Length
+//	----------------------+----------
+//	Transaction chunk     | arbitrary
+//
+// The transaction is the RLP encoding of the unsigned legacy transaction, or of the
+// EIP-155 one if a chain ID is given. Typed transactions (EIP-2718) are prefixed by
+// their type, and their RLP encoding holds the chain ID.
+//
+// And the output data is:
+//
+//	Description | Length
+//	------------+---------
+//	signature V | 1 byte
+//	signature R | 32 bytes
+//	signature S | 32 bytes
+//
+// For EIP-155 transactions, V only holds the lowest byte of chainID * 2 + 35 + parity,
+// while typed transactions return the parity itself.
+func (w *ledgerDriver) ledgerSign(derivationPath gethaccounts.DerivationPath, tx *types.Transaction, chainID *big.Int) (common.Address, *types.Transaction, error) {
+	// Flatten the derivation path into the Ledger request
+	path := make([]byte, 1+4*len(derivationPath))
+	path[0] = byte(len(derivationPath))
+	for i, component := range derivationPath {
+		binary.BigEndian.PutUint32(path[1+4*i:], component)
+	}
+	// Create the transaction RLP based on the transaction type and whether legacy or
+	// EIP155 signing was requested
+	var (
+		txrlp []byte
+		err   error
+	)
+	switch {
+	case tx.Type() != types.LegacyTxType && chainID == nil:
+		return common.Address{}, nil, fmt.Errorf("chain ID required to sign transactions of type %d", tx.Type())
+	case tx.Type() == types.AccessListTxType:
+		txrlp, err = rlp.EncodeToBytes([]interface{}{chainID, tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), tx.AccessList()})
+	case tx.Type() == types.DynamicFeeTxType:
+		txrlp, err = rlp.EncodeToBytes([]interface{}{chainID, tx.Nonce(), tx.GasTipCap(), tx.GasFeeCap(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), tx.AccessList()})
+	case tx.Type() != types.LegacyTxType:
+		return common.Address{}, nil, fmt.Errorf("unsupported transaction type %d", tx.Type())
+	case chainID == nil:
+		txrlp, err = rlp.EncodeToBytes([]interface{}{tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.To(), tx.Value(), tx.Data()})
+	default:
+		txrlp, err = rlp.EncodeToBytes([]interface{}{tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), chainID, big.NewInt(0), big.NewInt(0)})
+	}
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	var payload []byte
+	payload = append(payload, path...)
+	if tx.Type() != types.LegacyTxType {
+		payload = append(payload, tx.Type())
+	}
+	payload = append(payload, txrlp...)
+
+	// Send the request and wait for the response
+	var (
+		op    = ledgerP1InitTransactionData
+		reply []byte
+	)
+	for len(payload) > 0 {
+		// Calculate the size of the next data chunk
+		chunk := 255
+		if chunk > len(payload) {
+			chunk = len(payload)
+		}
+		// Send the chunk over, ensuring it's processed correctly
+		reply, err = w.ledgerExchange(ledgerOpSignTransaction, op, 0, payload[:chunk])
+		if err != nil {
+			return common.Address{}, nil, err
+		}
+		// Shift the payload and ensure subsequent chunks are marked as such
+		payload = payload[chunk:]
+		op = ledgerP1ContTransactionData
+	}
+
+	// Extract the Ethereum signature and do a sanity validation
+	if len(reply) != crypto.SignatureLength {
+		return common.Address{}, nil, fmt.Errorf("%w: reply lacks signature", ErrShortResponse)
+	}
+
+	var signature []byte
+	signature = append(signature, reply[1:]...)
+	signature = append(signature, reply[0])
+
+	// Create the correct signer and signature transform based on the chain ID, so
+	// that V holds the parity of the signature
+	var signer types.Signer
+	switch {
+	case chainID == nil:
+		signer = new(types.HomesteadSigner)
+		if signature[crypto.RecoveryIDOffset] >= 27 {
+			signature[crypto.RecoveryIDOffset] -= 27
+		}
+	case tx.Type() == types.LegacyTxType:
+		signer = types.NewEIP155Signer(chainID)
+		signature[crypto.RecoveryIDOffset] -= byte(chainID.Uint64()*2 + 35)
+	default:
+		signer = types.NewLondonSigner(chainID)
+	}
+
+	signed, err := tx.WithSignature(signer, signature)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	sender, err := types.Sender(signer, signed)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	return sender, signed, nil
 }
 
 // ledgerSignTypedMessage sends the transaction to the Ledger wallet, and waits for the user
@@ -333,14 +854,145 @@ func (w *ledgerDriver) ledgerSignTypedMessage(derivationPath gethaccounts.Deriva
 	)
 
 	// Send the message over, ensuring it's processed correctly
-	reply, err = w.ledgerExchange(ledgerOpSignTypedMessage, op, 0, payload)
+	reply, err = w.ledgerExchange(ledgerOpSignTypedMessage, op, ledgerP2HashedTypedMessage, payload)
 	if err != nil {
 		return nil, err
 	}
 
 	// Extract the Ethereum signature and do a sanity validation
 	if len(reply) != crypto.SignatureLength {
-		return nil, errors.New("reply lacks signature")
+		return nil, fmt.Errorf("%w: reply lacks signature", ErrShortResponse)
+	}
+
+	var signature []byte
+	signature = append(signature, reply[1:]...)
+	signature = append(signature, reply[0])
+
+	return signature, nil
+}
+
+// ledgerProvideTokenInfo sends a signed ERC-20 token descriptor to the Ledger, which
+// checks its signature and keeps it to display the amounts of the next request.
+//
+// The token information protocol is defined as follows:
+//
+//	CLA | INS | P1 | P2 | Lc       | Le
+//	----+-----+----+----+----------+---
+//	 E0 | 0A  | 00 | 00 | variable | 01
+//
+// Where the input data is:
+//
+//	Description                  | Length
+//	-----------------------------+----------
+//	Ticker length                | 1 byte
+//	Ticker                       | variable
+//	Contract address             | 20 bytes
+//	Decimals (big endian)        | 4 bytes
+//	Chain ID (big endian)        | 4 bytes
+//	Token information signature  | variable
+//
+// And the output data is the index assigned to the token by the device (1 byte).
+func (w *ledgerDriver) ledgerProvideTokenInfo(token accounts.TokenInfo) error {
+	if len(token.Ticker) == 0 || len(token.Ticker) > 0xff {
+		return fmt.Errorf("invalid token ticker length: %d", len(token.Ticker))
+	}
+	if len(token.Signature) == 0 {
+		return errors.New("token information is not signed")
+	}
+
+	var payload []byte
+	payload = append(payload, byte(len(token.Ticker)))
+	payload = append(payload, token.Ticker...)
+	payload = append(payload, token.Address.Bytes()...)
+	payload = binary.BigEndian.AppendUint32(payload, token.Decimals)
+	payload = binary.BigEndian.AppendUint32(payload, token.ChainID)
+	payload = append(payload, token.Signature...)
+
+	if len(payload) > 0xff {
+		return fmt.Errorf("token information too large: %d bytes", len(payload))
+	}
+
+	_, err := w.ledgerExchange(ledgerOpProvideTokenInfo, 0, 0, payload)
+	return err
+}
+
+// ledgerSignPersonalMessage sends the message to the Ledger wallet, and waits for the
+// user to confirm or deny it.
+//
+// The signing protocol is defined as follows:
+//
+//	CLA | INS | P1 | P2 | Lc  | Le
+//	----+-----+----+----+-----+---
+//	 E0 | 08  | 00: first message data block
+//	            80: subsequent message data block
+//	               | 00 | variable | variable
+//
+// Where the input for the first message block (first 255 bytes) is:
+//
+//	Description                                      | Length
+//	-------------------------------------------------+----------
+//	Number of BIP 32 derivations to perform (max 10) | 1 byte
+//	First derivation index (big endian)              | 4 bytes
+//	...                                              | 4 bytes
+//	Last derivation index (big endian)               | 4 bytes
+//	Message length (big endian)                      | 4 bytes
+//	Message chunk                                    | arbitrary
+//
+// And the input for subsequent message blocks (if input larger than 255 bytes) is:
+//
+//	Description           | Length
+//	----------------------+----------
+//	Message chunk         | arbitrary
+//
+// And the output data is:
+//
+//	Description | Length
+//	------------+---------
+//	signature V | 1 byte
+//	signature R | 32 bytes
+//	signature S | 32 bytes
+func (w *ledgerDriver) ledgerSignPersonalMessage(derivationPath gethaccounts.DerivationPath, message []byte) ([]byte, error) {
+	// Flatten the derivation path into the Ledger request
+	path := make([]byte, 1+4*len(derivationPath))
+	path[0] = byte(len(derivationPath))
+	for i, component := range derivationPath {
+		binary.BigEndian.PutUint32(path[1+4*i:], component)
+	}
+	// Create the message payload, prefixed by its length
+	length := make([]byte, 4)
+	//#nosec G701 -- gosec will raise a warning on this integer conversion for potential overflow
+	binary.BigEndian.PutUint32(length, uint32(len(message)))
+
+	var payload []byte
+	payload = append(payload, path...)
+	payload = append(payload, length...)
+	payload = append(payload, message...)
+
+	// Send the request and wait for the response
+	var (
+		op    = ledgerP1InitPersonalMessageData
+		reply []byte
+		err   error
+	)
+	for len(payload) > 0 {
+		// Calculate the size of the next data chunk
+		chunk := 255
+		if chunk > len(payload) {
+			chunk = len(payload)
+		}
+		// Send the chunk over, ensuring it's processed correctly
+		reply, err = w.ledgerExchange(ledgerOpSignPersonalMessage, op, 0, payload[:chunk])
+		if err != nil {
+			return nil, err
+		}
+		// Shift the payload and ensure subsequent chunks are marked as such
+		payload = payload[chunk:]
+		op = ledgerP1ContPersonalMessageData
+	}
+
+	// Extract the Ethereum signature and do a sanity validation
+	if len(reply) != crypto.SignatureLength {
+		return nil, fmt.Errorf("%w: reply lacks signature", ErrShortResponse)
 	}
 
 	var signature []byte
@@ -383,15 +1035,50 @@ func (w *ledgerDriver) ledgerSignTypedMessage(derivationPath gethaccounts.Deriva
 //	APDU P2                  | 1 byte
 //	APDU length              | 1 byte
 //	Optional APDU data       | arbitrary
+//
+// The reply payload is terminated by a 2 byte status word (big endian), where
+// 9000 denotes success. Any other status word is returned as an APDUError.
 func (w *ledgerDriver) ledgerExchange(opcode ledgerOpcode, p1 ledgerParam1, p2 ledgerParam2, data []byte) ([]byte, error) {
+	return w.ledgerExchangeWithClass(ledgerClassEthereum, opcode, p1, p2, data)
+}
+
+// ledgerExchangeWithClass performs a data exchange with the Ledger wallet using
+// the given APDU instruction class (see ledgerExchange).
+func (w *ledgerDriver) ledgerExchangeWithClass(class ledgerClass, opcode ledgerOpcode, p1 ledgerParam1, p2 ledgerParam2, data []byte) ([]byte, error) {
 	// Construct the message payload, possibly split into multiple chunks
 	apdu := make([]byte, 2, 7+len(data))
 
 	//#nosec G701 -- gosec will raise a warning on this integer conversion for potential overflow
 	binary.BigEndian.PutUint16(apdu, uint16(5+len(data)))
-	apdu = append(apdu, []byte{0xe0, byte(opcode), byte(p1), byte(p2), byte(len(data))}...)
+	apdu = append(apdu, []byte{byte(class), byte(opcode), byte(p1), byte(p2), byte(len(data))}...)
 	apdu = append(apdu, data...)
 
+	reply, err := w.ledgerTransfer(apdu)
+	if w.recorder != nil {
+		w.recorder.record(apdu[2:], reply, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) < 2 {
+		return nil, fmt.Errorf("%w: reply lacks status word", ErrShortResponse)
+	}
+	// Split off the status word and make sure the request succeeded
+	status := binary.BigEndian.Uint16(reply[len(reply)-2:])
+	if status != ledgerSWSuccess {
+		return nil, &APDUError{StatusWord: status}
+	}
+	return reply[:len(reply)-2], nil
+}
+
+// ledgerTransfer streams the length prefixed APDU to the Ledger wallet in 64 byte
+// chunks and reassembles the reply, including its status word.
+func (w *ledgerDriver) ledgerTransfer(apdu []byte) ([]byte, error) {
+	// The command timeout, if any, bounds the whole exchange
+	if conn, ok := w.device.(commandConn); ok {
+		conn.startCommand()
+	}
+
 	// Stream all the chunks to the device
 	header := []byte{0x01, 0x01, 0x05, 0x00, 0x00} // Channel ID and command tag appended
 	chunk := make([]byte, 64)
@@ -412,7 +1099,7 @@ func (w *ledgerDriver) ledgerExchange(opcode ledgerOpcode, p1 ledgerParam1, p2 l
 		}
 		// Send over to the device
 		if _, err := w.device.Write(chunk); err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: %w", ErrDeviceIO, err)
 		}
 	}
 	// Stream the reply back from the wallet in 64 byte chunks
@@ -421,7 +1108,11 @@ func (w *ledgerDriver) ledgerExchange(opcode ledgerOpcode, p1 ledgerParam1, p2 l
 	for {
 		// Read the next chunk from the Ledger wallet
 		if _, err := io.ReadFull(w.device, chunk); err != nil {
-			return nil, err
+			// The device went away in the middle of the reply
+			if reply != nil && (errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)) {
+				return nil, fmt.Errorf("%w: %w: %w", ErrDeviceIO, ErrShortResponse, err)
+			}
+			return nil, fmt.Errorf("%w: %w", ErrDeviceIO, err)
 		}
 
 		// Make sure the transport header matches
@@ -446,5 +1137,5 @@ func (w *ledgerDriver) ledgerExchange(opcode ledgerOpcode, p1 ledgerParam1, p2 l
 			break
 		}
 	}
-	return reply[:len(reply)-2], nil
+	return reply, nil
 }
@@ -0,0 +1,65 @@
+package usbwallet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// apduHeaderLength is the length of the APDU command header: the instruction class,
+// the opcode, both parameters and the payload length.
+const apduHeaderLength = 5
+
+// APDURecorder writes a transcript of the APDU command/response pairs exchanged with
+// the devices, to help diagnose device issues. Each command is written on a line
+// prefixed with "=> " and each response on a line prefixed with "<= ", both hex
+// encoded, which is the format replayed by the Ledger transport mockers. Failed
+// exchanges are written on a line prefixed with "<! ".
+//
+// If redaction is enabled, the payloads of the commands and responses (e.g. the
+// derivation paths, transactions and public keys) are replaced with their length,
+// while the command headers and status words are kept, so the transcript can be
+// shared without disclosing the accounts involved. A redacted transcript cannot be
+// replayed.
+type APDURecorder struct {
+	mu     sync.Mutex
+	w      io.Writer
+	redact bool
+}
+
+// NewAPDURecorder creates a recorder writing the transcript to the given writer,
+// optionally redacting the payloads of the exchanges.
+func NewAPDURecorder(w io.Writer, redact bool) *APDURecorder {
+	return &APDURecorder{w: w, redact: redact}
+}
+
+// record writes the command and the response, including its status word, or the
+// error the exchange failed with. Failures to write the transcript are ignored, so
+// they never affect the exchange itself.
+func (r *APDURecorder) record(command, response []byte, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	//#nosec G104 -- the transcript is best-effort
+	fmt.Fprintf(r.w, "=> %s\n", r.format(command, apduHeaderLength, 0))
+	if err != nil {
+		//#nosec G104 -- the transcript is best-effort
+		fmt.Fprintf(r.w, "<! %v\n", err)
+		return
+	}
+	//#nosec G104 -- the transcript is best-effort
+	fmt.Fprintf(r.w, "<= %s\n", r.format(response, 0, 2))
+}
+
+// format hex encodes the message, replacing everything but the given number of
+// leading and trailing bytes with the length of the payload if redaction is enabled.
+func (r *APDURecorder) format(msg []byte, keepHead, keepTail int) string {
+	if !r.redact || len(msg) <= keepHead+keepTail {
+		return hex.EncodeToString(msg)
+	}
+
+	payload := len(msg) - keepHead - keepTail
+	return fmt.Sprintf("%s[redacted %d bytes]%s",
+		hex.EncodeToString(msg[:keepHead]), payload, hex.EncodeToString(msg[len(msg)-keepTail:]))
+}
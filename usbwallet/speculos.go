@@ -0,0 +1,157 @@
+package usbwallet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// speculosDialTimeout bounds the time spent connecting to the Speculos APDU server.
+const speculosDialTimeout = 5 * time.Second
+
+// errSpeculosInvalidReport is returned if a HID report written to the emulator is
+// not framed like the ones of the Ledger driver.
+var errSpeculosInvalidReport = errors.New("speculos: invalid HID report")
+
+// SpeculosTransport is the Transport reaching a Ledger emulated by Speculos through
+// its APDU server (e.g. "127.0.0.1:9999"), which allows running the whole signing
+// flow in CI without a physical device. It lists a single Ledger Nano X, whose HID
+// reports are translated to and from the length prefixed APDUs spoken over TCP.
+//
+// The emulator is not probed on enumeration, so the device is always listed and
+// opening it fails if the APDU server is unreachable.
+type SpeculosTransport struct {
+	endpoint string
+}
+
+var _ Transport = &SpeculosTransport{}
+
+// NewSpeculosTransport creates a transport reaching the Speculos APDU server at the
+// given TCP endpoint.
+func NewSpeculosTransport(endpoint string) *SpeculosTransport {
+	return &SpeculosTransport{endpoint: endpoint}
+}
+
+// Enumerate implements Transport, listing the emulated Ledger.
+func (t *SpeculosTransport) Enumerate(vendorID uint16) ([]DeviceInfo, error) {
+	return []DeviceInfo{{
+		Path:         t.endpoint,
+		VendorID:     vendorID,
+		ProductID:    0x4015, // HID + U2F + WebUSB Ledger Nano X
+		Manufacturer: "Ledger",
+		Product:      "Speculos",
+		UsagePage:    0xffa0,
+		Interface:    0,
+	}}, nil
+}
+
+// Open implements Transport, connecting to the Speculos APDU server.
+func (t *SpeculosTransport) Open(DeviceInfo) (io.ReadWriteCloser, error) {
+	conn, err := net.DialTimeout("tcp", t.endpoint, speculosDialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("speculos: %w", err)
+	}
+	return &speculosConn{conn: conn}, nil
+}
+
+// speculosConn translates the 64 byte HID reports of the Ledger protocol to the
+// APDU framing of the Speculos server: each command is sent prefixed with its
+// 4 byte big endian length, and each response is received prefixed with the length
+// of its data, excluding the trailing 2 byte status word.
+type speculosConn struct {
+	conn net.Conn
+
+	command []byte   // APDU being reassembled from the written reports
+	length  int      // Total length of the APDU being reassembled
+	reports [][]byte // HID reports of the response not read yet
+}
+
+// Write implements io.Writer, sending the APDU to the server once all of its
+// reports are written.
+func (c *speculosConn) Write(report []byte) (int, error) {
+	if len(report) < 5 || report[0] != 0x01 || report[1] != 0x01 || report[2] != 0x05 {
+		return 0, errSpeculosInvalidReport
+	}
+
+	payload := report[5:]
+	if binary.BigEndian.Uint16(report[3:5]) == 0 {
+		if len(payload) < 2 {
+			return 0, errSpeculosInvalidReport
+		}
+		c.length = int(binary.BigEndian.Uint16(payload))
+		c.command = make([]byte, 0, c.length)
+		payload = payload[2:]
+	}
+
+	if left := c.length - len(c.command); left < len(payload) {
+		payload = payload[:left]
+	}
+	c.command = append(c.command, payload...)
+	if len(c.command) < c.length {
+		return len(report), nil
+	}
+
+	frame := make([]byte, 4, 4+len(c.command))
+	//#nosec G701 -- gosec will raise a warning on this integer conversion for potential overflow
+	binary.BigEndian.PutUint32(frame, uint32(len(c.command)))
+	frame = append(frame, c.command...)
+	c.command = nil
+
+	if _, err := c.conn.Write(frame); err != nil {
+		return 0, err
+	}
+	return len(report), nil
+}
+
+// Read implements io.Reader, returning the next HID report of the response, which
+// is received from the server when the previous one was fully read.
+func (c *speculosConn) Read(b []byte) (int, error) {
+	if len(c.reports) == 0 {
+		if err := c.receive(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(b, c.reports[0])
+	c.reports = c.reports[1:]
+	return n, nil
+}
+
+// receive reads the next response from the server and splits it into HID reports.
+func (c *speculosConn) receive() error {
+	var header [4]byte
+	if _, err := io.ReadFull(c.conn, header[:]); err != nil {
+		return err
+	}
+
+	// The length excludes the status word
+	response := make([]byte, binary.BigEndian.Uint32(header[:])+2)
+	if _, err := io.ReadFull(c.conn, response); err != nil {
+		return err
+	}
+
+	msg := make([]byte, 2, 2+len(response))
+	//#nosec G701 -- gosec will raise a warning on this integer conversion for potential overflow
+	binary.BigEndian.PutUint16(msg, uint16(len(response)))
+	msg = append(msg, response...)
+
+	for i := 0; len(msg) > 0; i++ {
+		report := make([]byte, 64)
+		copy(report, []byte{0x01, 0x01, 0x05})
+		//#nosec G701 -- gosec will raise a warning on this integer conversion for potential overflow
+		binary.BigEndian.PutUint16(report[3:], uint16(i))
+
+		n := copy(report[5:], msg)
+		msg = msg[n:]
+		c.reports = append(c.reports, report)
+	}
+	return nil
+}
+
+// Close implements io.Closer, disconnecting from the server.
+func (c *speculosConn) Close() error {
+	return c.conn.Close()
+}
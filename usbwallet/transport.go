@@ -0,0 +1,263 @@
+package usbwallet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	usb "github.com/zondax/hid"
+)
+
+// DeviceInfo describes a hardware wallet device reachable through a Transport.
+type DeviceInfo = usb.DeviceInfo
+
+// Transport abstracts the discovery of hardware wallet devices and the connection
+// to them, so that a Hub can reach devices that are not directly accessible as HID
+// devices, e.g. through a TCP or named-pipe bridge from a sandboxed environment.
+//
+// The devices listed by Enumerate must report one of the product identifiers of
+// the Hub, as well as its usage page (Windows and macOS) or interface (Linux).
+type Transport interface {
+	// Enumerate lists the devices of the given vendor currently reachable through
+	// the transport.
+	Enumerate(vendorID uint16) ([]DeviceInfo, error)
+
+	// Open connects to the given device. The returned connection exchanges the
+	// vendor specific USB HID reports with the device.
+	Open(info DeviceInfo) (io.ReadWriteCloser, error)
+}
+
+// HIDTransport is the Transport reaching hardware wallets directly as USB HID
+// devices. It is the transport used by NewLedgerHub.
+type HIDTransport struct{}
+
+var _ Transport = HIDTransport{}
+
+// Enumerate implements Transport, listing the USB HID devices of the given vendor.
+// As hidapi does not report enumeration failures, none are returned.
+func (HIDTransport) Enumerate(vendorID uint16) ([]DeviceInfo, error) {
+	return usb.Enumerate(vendorID, 0), nil
+}
+
+// ErrDevicePermission is returned (wrapped) when the user lacks the permissions to
+// access the USB device, which on Linux means that the Ledger udev rules are missing.
+var ErrDevicePermission = errors.New("ledger: permission denied accessing the device, please install the Ledger udev rules")
+
+// Open implements Transport, opening the USB HID device. Since hidapi doesn't
+// report why a device could not be opened, the usual causes are told apart
+// afterwards: the device is reported busy (ErrDeviceBusy) only if it is still
+// connected and accessible, in which case another application holds it.
+func (HIDTransport) Open(info DeviceInfo) (io.ReadWriteCloser, error) {
+	device, err := info.Open()
+	if err == nil {
+		return device, nil
+	}
+
+	if !hidDeviceConnected(info) {
+		return nil, fmt.Errorf("device disconnected: %w", err)
+	}
+	if permErr := hidPermissionError(info); permErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDevicePermission, permErr)
+	}
+	return nil, fmt.Errorf("%w: %w", ErrDeviceBusy, err)
+}
+
+// hidDeviceConnected reports whether the device is still listed by hidapi.
+func hidDeviceConnected(info DeviceInfo) bool {
+	for _, device := range usb.Enumerate(info.VendorID, info.ProductID) {
+		if device.Path == info.Path {
+			return true
+		}
+	}
+	return false
+}
+
+// hidPermissionError returns the error opening the USB device node if the user
+// lacks the permissions to access it. On Linux, hidapi reaches the devices through
+// libusb, whose device paths are formatted as "bus:address:interface" in hex. The
+// permissions are not checked on the other platforms, where no udev rules are
+// required.
+func hidPermissionError(info DeviceInfo) error {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+
+	var bus, address, iface int
+	if _, err := fmt.Sscanf(info.Path, "%x:%x:%x", &bus, &address, &iface); err != nil {
+		return nil
+	}
+
+	node, err := os.OpenFile(fmt.Sprintf("/dev/bus/usb/%03d/%03d", bus, address), os.O_RDWR, 0)
+	if err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return err
+		}
+		return nil
+	}
+	//#nosec G104 -- the node was only opened to check the permissions
+	node.Close()
+	return nil
+}
+
+// ErrCommandTimeout is returned (wrapped) when the device doesn't complete a
+// command within the command timeout of the hub (see Hub.SetCommandTimeout).
+var ErrCommandTimeout = errors.New("ledger: device did not reply to the command in time")
+
+// commandConn is implemented by the connections bounding the time spent on each
+// APDU, which the driver notifies at the start of every APDU it exchanges.
+type commandConn interface {
+	// startCommand starts the deadline of the APDU about to be exchanged.
+	startCommand()
+}
+
+// errConnClosed is returned by the operations of a timeoutConn once it is closed.
+var errConnClosed = errors.New("ledger: connection closed")
+
+// timeoutConn bounds the time spent on each APDU exchanged over a device connection,
+// across all of the HID reports of the command and its reply. Once an operation
+// times out, it is abandoned and all further operations fail.
+//
+// The underlying connection is never closed while an operation is pending on it,
+// since closing a HID handle under a blocked read or write frees the handle while it
+// is in use. If an operation times out or the connection is closed while one is
+// pending, the connection is closed once the operation returns.
+type timeoutConn struct {
+	conn    io.ReadWriteCloser
+	timeout time.Duration
+
+	mu       sync.Mutex
+	deadline time.Time // Deadline of the current APDU, per operation if zero
+	pending  bool      // Set while an operation runs on the connection
+	expired  bool      // Set once an operation timed out
+	closing  bool      // Set once the connection must be closed, as soon as it is idle
+	closed   bool      // Set once the underlying connection is closed
+}
+
+var _ commandConn = &timeoutConn{}
+
+// startCommand implements commandConn, starting the deadline of the next APDU.
+func (c *timeoutConn) startCommand() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.deadline = time.Now().Add(c.timeout)
+}
+
+// Read implements io.Reader, failing with ErrCommandTimeout if the reply is not
+// received in time.
+func (c *timeoutConn) Read(b []byte) (int, error) {
+	return c.do(b, c.conn.Read)
+}
+
+// Write implements io.Writer, failing with ErrCommandTimeout if the command is not
+// sent in time.
+func (c *timeoutConn) Write(b []byte) (int, error) {
+	return c.do(b, c.conn.Write)
+}
+
+// Close implements io.Closer. If an operation is pending, the connection is closed
+// once it returns.
+func (c *timeoutConn) Close() error {
+	c.mu.Lock()
+	c.closing = true
+	release := c.release()
+	c.mu.Unlock()
+
+	if !release {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// release reports whether the underlying connection must be closed now, i.e. if it
+// must be closed, no operation is pending on it and it wasn't closed yet, in which
+// case it is marked closed.
+//
+// Note, release assumes the lock is held!
+func (c *timeoutConn) release() bool {
+	if !c.closing || c.pending || c.closed {
+		return false
+	}
+	c.closed = true
+	return true
+}
+
+// do runs the operation on a copy of the buffer, so that an operation completing
+// after its timeout doesn't touch the buffer of the caller.
+func (c *timeoutConn) do(b []byte, op func([]byte) (int, error)) (int, error) {
+	c.mu.Lock()
+	switch {
+	case c.expired:
+		c.mu.Unlock()
+		return 0, ErrCommandTimeout
+	case c.closing:
+		c.mu.Unlock()
+		return 0, errConnClosed
+	}
+
+	timeout := c.timeout
+	if !c.deadline.IsZero() {
+		timeout = time.Until(c.deadline)
+	}
+	if timeout <= 0 {
+		c.mu.Unlock()
+		return 0, c.expire()
+	}
+	c.pending = true
+	c.mu.Unlock()
+
+	type result struct {
+		n   int
+		err error
+	}
+	// Buffered so the operation can always deliver its result and exit
+	resCh := make(chan result, 1)
+	buf := append([]byte(nil), b...)
+
+	go func() {
+		n, err := op(buf)
+
+		// Close the connection if it was abandoned or closed in the meantime
+		c.mu.Lock()
+		c.pending = false
+		release := c.release()
+		c.mu.Unlock()
+
+		if release {
+			//#nosec G703 -- the connection is abandoned, so a failure to close it is not relevant
+			_ = c.conn.Close()
+		}
+		resCh <- result{n: n, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-resCh:
+		copy(b, buf[:res.n])
+		return res.n, res.err
+	case <-timer.C:
+		return 0, c.expire()
+	}
+}
+
+// expire abandons the connection after a timeout, closing it right away if no
+// operation is pending on it, e.g. because the operation returned right before the
+// timeout, and returns the timeout error.
+func (c *timeoutConn) expire() error {
+	c.mu.Lock()
+	c.expired, c.closing = true, true
+	release := c.release()
+	c.mu.Unlock()
+
+	if release {
+		//#nosec G703 -- the connection is abandoned, so a failure to close it is not relevant
+		_ = c.conn.Close()
+	}
+	return fmt.Errorf("%w after %s", ErrCommandTimeout, c.timeout)
+}
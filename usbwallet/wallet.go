@@ -23,15 +23,16 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"sync"
 	"time"
 
 	gethaccounts "github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/evmos/evmos-ledger-go/accounts"
-	usb "github.com/zondax/hid"
 )
 
 // Maximum time between wallet health checks to detect USB unplugs.
@@ -52,17 +53,60 @@ type driver interface {
 	// Close releases any resources held by an open wallet instance.
 	Close() error
 
+	// AppConfiguration retrieves the configuration of the app running on the device.
+	AppConfiguration() (accounts.AppConfiguration, error)
+
+	// RunningApp retrieves the name and version of the app running on the device.
+	RunningApp() (accounts.AppInfo, error)
+
+	// OpenApp requests the device dashboard to launch the app with the given name.
+	OpenApp(name string) error
+
+	// ListApps requests the device dashboard to list the installed apps.
+	ListApps() ([]accounts.AppInfo, error)
+
 	// Heartbeat performs a sanity check against the hardware wallet to see if it
 	// is still online and healthy.
 	Heartbeat() error
 
 	// Derive sends a derivation request to the USB device and returns the Ethereum
-	// address located on that path.
-	Derive(path gethaccounts.DerivationPath) (common.Address, *ecdsa.PublicKey, error)
+	// address located on that path. If display is set, the device shows the address
+	// and waits for the user to confirm it before replying.
+	Derive(path gethaccounts.DerivationPath, display bool) (common.Address, *ecdsa.PublicKey, error)
+
+	// DeriveWithChainCode behaves like Derive, but additionally returns the BIP-32
+	// chain code of the node located on that path.
+	DeriveWithChainCode(path gethaccounts.DerivationPath) (common.Address, *ecdsa.PublicKey, []byte, error)
+
+	// SignTx sends the transaction to the USB device and waits for the user to confirm
+	// or deny the transaction.
+	SignTx(path gethaccounts.DerivationPath, tx *types.Transaction, chainID *big.Int) (common.Address, *types.Transaction, error)
 
 	// SignTypedMessage sends the message to the Ledger and waits for the user to sign
 	// or deny the transaction.
 	SignTypedMessage(path gethaccounts.DerivationPath, messageHash []byte, domainHash []byte) ([]byte, error)
+
+	// SignTypedMessageFull streams the whole typed data to the Ledger, which displays
+	// its fields, and waits for the user to sign or deny it.
+	SignTypedMessageFull(path gethaccounts.DerivationPath, typedData apitypes.TypedData) ([]byte, error)
+
+	// ProvideTokenInfo sends a signed ERC-20 token descriptor to the device, which
+	// uses it to display the token amounts of the next signing request.
+	ProvideTokenInfo(token accounts.TokenInfo) error
+
+	// SignPersonalMessage sends the message to the Ledger to be signed following the
+	// EIP-191 personal_sign scheme, and waits for the user to sign or deny it.
+	SignPersonalMessage(path gethaccounts.DerivationPath, message []byte) ([]byte, error)
+}
+
+// recordingDriver is implemented by the drivers able to record the APDUs exchanged
+// with the device (see Hub.SetAPDURecorder).
+type recordingDriver interface {
+	driver
+
+	// setRecorder sets the recorder of the APDUs exchanged with the device, or
+	// disables recording if nil.
+	setRecorder(recorder *APDURecorder)
 }
 
 // wallet represents the common functionality shared by all USB hardware
@@ -73,8 +117,8 @@ type wallet struct {
 	driver driver            // Hardware implementation of the low level device operations
 	url    *gethaccounts.URL // Textual URL uniquely identifying this wallet
 
-	info   usb.DeviceInfo // Known USB device infos about the wallet
-	device *usb.Device    // USB device advertising itself as a hardware wallet
+	info   DeviceInfo         // Known USB device infos about the wallet
+	device io.ReadWriteCloser // USB device advertising itself as a hardware wallet
 
 	accounts []accounts.Account                             // List of derive accounts pinned on the hardware wallet
 	paths    map[common.Address]gethaccounts.DerivationPath // Known derivation paths for signing operations
@@ -109,6 +153,24 @@ func (w *wallet) URL() gethaccounts.URL {
 	return *w.url // Immutable, no need for a lock
 }
 
+// Info implements accounts.Wallet, returning the USB descriptor details of the
+// hardware device.
+func (w *wallet) Info() accounts.DeviceInfo {
+	// Immutable, no need for a lock
+	return accounts.DeviceInfo{
+		Path:         w.info.Path,
+		VendorID:     w.info.VendorID,
+		ProductID:    w.info.ProductID,
+		Release:      w.info.Release,
+		Manufacturer: w.info.Manufacturer,
+		Product:      w.info.Product,
+		Serial:       w.info.Serial,
+	}
+}
+
+// StatusClosed is the status reported by wallets that are not open.
+const StatusClosed = "Closed"
+
 // Status implements accounts.Wallet, returning a custom status message from the
 // underlying vendor-specific hardware wallet implementation.
 func (w *wallet) Status() (string, error) {
@@ -117,11 +179,93 @@ func (w *wallet) Status() (string, error) {
 
 	status, failure := w.driver.Status()
 	if w.device == nil {
-		return "Closed", failure
+		return StatusClosed, failure
 	}
 	return status, failure
 }
 
+// AppConfiguration implements accounts.Wallet, querying the device for the
+// configuration of the app currently running on it.
+func (w *wallet) AppConfiguration() (accounts.AppConfiguration, error) {
+	w.stateLock.RLock() // Avoid device disappearing during the request
+	defer w.stateLock.RUnlock()
+
+	if w.device == nil {
+		return accounts.AppConfiguration{}, gethaccounts.ErrWalletClosed
+	}
+	<-w.commsLock // Avoid concurrent hardware access
+	defer func() { w.commsLock <- struct{}{} }()
+
+	return w.driver.AppConfiguration()
+}
+
+// RunningApp implements accounts.Wallet, querying the device for the name and
+// version of the app currently running on it.
+func (w *wallet) RunningApp() (accounts.AppInfo, error) {
+	w.stateLock.RLock() // Avoid device disappearing during the request
+	defer w.stateLock.RUnlock()
+
+	if w.device == nil {
+		return accounts.AppInfo{}, gethaccounts.ErrWalletClosed
+	}
+	<-w.commsLock // Avoid concurrent hardware access
+	defer func() { w.commsLock <- struct{}{} }()
+
+	return w.driver.RunningApp()
+}
+
+// OpenApp implements accounts.Wallet, requesting the device to launch the app with
+// the given name and waiting for the user to confirm it.
+func (w *wallet) OpenApp(name string) error {
+	w.stateLock.RLock() // Avoid device disappearing during the request
+	defer w.stateLock.RUnlock()
+
+	if w.device == nil {
+		return gethaccounts.ErrWalletClosed
+	}
+	<-w.commsLock // Avoid concurrent hardware access
+	defer func() { w.commsLock <- struct{}{} }()
+
+	// Ensure the device isn't screwed with while user confirmation is pending
+	w.hub.commsLock.Lock()
+	w.hub.commsPend++
+	w.hub.commsLock.Unlock()
+
+	defer func() {
+		w.hub.commsLock.Lock()
+		w.hub.commsPend--
+		w.hub.commsLock.Unlock()
+	}()
+
+	return w.driver.OpenApp(name)
+}
+
+// ListApps implements accounts.Wallet, querying the device dashboard for the apps
+// installed on it.
+func (w *wallet) ListApps() ([]accounts.AppInfo, error) {
+	w.stateLock.RLock() // Avoid device disappearing during the request
+	defer w.stateLock.RUnlock()
+
+	if w.device == nil {
+		return nil, gethaccounts.ErrWalletClosed
+	}
+	<-w.commsLock // Avoid concurrent hardware access
+	defer func() { w.commsLock <- struct{}{} }()
+
+	return w.driver.ListApps()
+}
+
+var (
+	// ErrDeviceBusy is returned (wrapped) when the USB device cannot be opened because
+	// another application, such as Ledger Live, holds it.
+	ErrDeviceBusy = errors.New("ledger: device is busy, please close Ledger Live or any other app using it and retry")
+
+	// ErrDeviceOpen is returned (wrapped) when the device cannot be opened for any
+	// other reason, e.g. because it was unplugged, the user lacks the permissions to
+	// access it or the transport could not reach it.
+	ErrDeviceOpen = errors.New("ledger: could not open device")
+)
+
 // Open implements accounts.Wallet, attempting to open a USB connection to the
 // hardware wallet.
 func (w *wallet) Open(passphrase string) error {
@@ -134,14 +278,24 @@ func (w *wallet) Open(passphrase string) error {
 	}
 	// Make sure the actual device connection is done only once
 	if w.device == nil {
-		device, err := w.info.Open()
+		device, err := w.hub.transport.Open(w.info)
 		if err != nil {
-			return err
+			// Only the transport can tell whether the device is held by another application
+			if errors.Is(err, ErrDeviceBusy) {
+				return err
+			}
+			return fmt.Errorf("%w: %w", ErrDeviceOpen, err)
+		}
+		if timeout := time.Duration(w.hub.commandTimeout.Load()); timeout > 0 {
+			device = &timeoutConn{conn: device, timeout: timeout}
 		}
 		w.device = device
 		w.commsLock = make(chan struct{}, 1)
 		w.commsLock <- struct{}{} // Enable lock
 	}
+	if driver, ok := w.driver.(recordingDriver); ok {
+		driver.setRecorder(w.hub.recorder.Load())
+	}
 	// Delegate device initialization to the underlying driver
 	if err := w.driver.Open(w.device, passphrase); err != nil {
 		return err
@@ -277,6 +431,19 @@ func (w *wallet) Contains(account accounts.Account) bool {
 // derivation path. If pin is set to true, the account will be added to the list
 // of tracked accounts.
 func (w *wallet) Derive(path gethaccounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return w.derive(path, pin, false)
+}
+
+// DeriveWithDisplay implements accounts.Wallet, deriving a new account at the
+// specific derivation path after the user confirmed the address shown on the
+// device. If pin is set to true, the account will be added to the list of tracked
+// accounts.
+func (w *wallet) DeriveWithDisplay(path gethaccounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return w.derive(path, pin, true)
+}
+
+// derive is the internal implementation of Derive and DeriveWithDisplay.
+func (w *wallet) derive(path gethaccounts.DerivationPath, pin, display bool) (accounts.Account, error) {
 	formatPathIfNeeded(path)
 
 	// Try to derive the actual account and update its URL if successful
@@ -287,7 +454,20 @@ func (w *wallet) Derive(path gethaccounts.DerivationPath, pin bool) (accounts.Ac
 		return accounts.Account{}, gethaccounts.ErrWalletClosed
 	}
 	<-w.commsLock // Avoid concurrent hardware access
-	address, publicKey, err := w.driver.Derive(path)
+
+	if display {
+		// Ensure the device isn't screwed with while user confirmation is pending
+		// TODO(karalabe): remove if hotplug lands on Windows
+		w.hub.commsLock.Lock()
+		w.hub.commsPend++
+		w.hub.commsLock.Unlock()
+	}
+	address, publicKey, err := w.driver.Derive(path, display)
+	if display {
+		w.hub.commsLock.Lock()
+		w.hub.commsPend--
+		w.hub.commsLock.Unlock()
+	}
 	w.commsLock <- struct{}{}
 
 	w.stateLock.RUnlock()
@@ -300,6 +480,7 @@ func (w *wallet) Derive(path gethaccounts.DerivationPath, pin bool) (accounts.Ac
 	account := accounts.Account{
 		Address:   address,
 		PublicKey: publicKey,
+		URL:       gethaccounts.URL{Scheme: w.url.Scheme, Path: fmt.Sprintf("%s/%s", w.url.Path, path)},
 	}
 	if !pin {
 		return account, nil
@@ -316,10 +497,38 @@ func (w *wallet) Derive(path gethaccounts.DerivationPath, pin bool) (accounts.Ac
 	return account, nil
 }
 
+// DeriveWithChainCode implements accounts.Wallet, deriving the account at the
+// specific derivation path along with the BIP-32 chain code of its node. The
+// account is not pinned, since the path usually points to a non-leaf node.
+func (w *wallet) DeriveWithChainCode(path gethaccounts.DerivationPath) (accounts.Account, []byte, error) {
+	formatPathIfNeeded(path)
+
+	w.stateLock.RLock() // Avoid device disappearing during derivation
+	defer w.stateLock.RUnlock()
+
+	if w.device == nil {
+		return accounts.Account{}, nil, gethaccounts.ErrWalletClosed
+	}
+	<-w.commsLock // Avoid concurrent hardware access
+	defer func() { w.commsLock <- struct{}{} }()
+
+	address, publicKey, chainCode, err := w.driver.DeriveWithChainCode(path)
+	if err != nil {
+		return accounts.Account{}, nil, err
+	}
+
+	account := accounts.Account{
+		Address:   address,
+		PublicKey: publicKey,
+		URL:       gethaccounts.URL{Scheme: w.url.Scheme, Path: fmt.Sprintf("%s/%s", w.url.Path, path)},
+	}
+	return account, chainCode, nil
+}
+
 // Format the hd path to harden the first three values (purpose, coinType, account)
 // if needed, modifying the array in-place.
 func formatPathIfNeeded(path gethaccounts.DerivationPath) {
-	for i := 0; i < 3; i++ {
+	for i := 0; i < 3 && i < len(path); i++ {
 		if path[i] < 0x80000000 {
 			path[i] += 0x80000000
 		}
@@ -328,6 +537,11 @@ func formatPathIfNeeded(path gethaccounts.DerivationPath) {
 
 // signHash implements accounts.Wallet, however signing arbitrary data is not
 // supported for hardware wallets, so this method will always return an error.
+//
+// Note, the Ledger Ethereum app has no instruction to sign a caller-provided hash,
+// even with blind signing enabled: blind signing only allows signing transactions
+// with contract data the app cannot decode, and the app always computes the signed
+// hash itself from the transaction or message it receives.
 func (w *wallet) signHash(_ accounts.Account, _ []byte) ([]byte, error) {
 	return nil, gethaccounts.ErrNotSupported
 }
@@ -340,6 +554,15 @@ func (w *wallet) signData(account accounts.Account, mimeType string, data []byte
 	}
 
 	// dispatch to 712 signing if the mimetype is TypedData and the format matches
+	return w.signWithDevice(account, func(path gethaccounts.DerivationPath) ([]byte, error) {
+		return w.driver.SignTypedMessage(path, data[2:34], data[34:66])
+	})
+}
+
+// signWithDevice looks up the derivation path of the account and runs the given
+// signing request against the device, ensuring exclusive access while the user
+// confirmation is pending.
+func (w *wallet) signWithDevice(account accounts.Account, sign func(path gethaccounts.DerivationPath) ([]byte, error)) ([]byte, error) {
 	w.stateLock.RLock() // Comms have own mutex, this is for the state fields
 	defer w.stateLock.RUnlock()
 
@@ -367,15 +590,17 @@ func (w *wallet) signData(account accounts.Account, mimeType string, data []byte
 		w.hub.commsPend--
 		w.hub.commsLock.Unlock()
 	}()
-	// Sign the transaction
-	signature, err := w.driver.SignTypedMessage(path, data[2:34], data[34:66])
+	// Sign the payload
+	signature, err := sign(path)
 	if err != nil {
 		return nil, err
 	}
 	return signature, nil
 }
 
-func (w *wallet) verifyTypedDataSignature(account accounts.Account, rawData []byte, signature []byte) error {
+// verifySignature checks that the signature over keccak256(rawData) was produced by
+// the private key of the given account.
+func (w *wallet) verifySignature(account accounts.Account, rawData []byte, signature []byte) error {
 	if len(signature) != crypto.SignatureLength {
 		return fmt.Errorf("invalid signature length: %d", len(signature))
 	}
@@ -384,8 +609,10 @@ func (w *wallet) verifyTypedDataSignature(account accounts.Account, rawData []by
 	sigCopy := make([]byte, len(signature))
 	copy(sigCopy, signature)
 
-	// Subtract 27 to match ECDSA standard
-	sigCopy[crypto.RecoveryIDOffset] -= 27
+	// Subtract 27 to match ECDSA standard, unless the app already returned V as 0 or 1
+	if sigCopy[crypto.RecoveryIDOffset] >= 27 {
+		sigCopy[crypto.RecoveryIDOffset] -= 27
+	}
 
 	hash := crypto.Keccak256(rawData)
 
@@ -419,7 +646,117 @@ func (w *wallet) SignTypedData(account accounts.Account, typedData apitypes.Type
 	}
 
 	// Verify recovered public key matches expected value
-	if err = w.verifyTypedDataSignature(account, rawDataBz, sigBytes); err != nil {
+	if err = w.verifySignature(account, rawDataBz, sigBytes); err != nil {
+		return nil, err
+	}
+
+	return sigBytes, nil
+}
+
+// SignTypedDataWithTokens behaves like SignTypedData, but first provides the given
+// token descriptors to the device within the same exclusive session, so that it can
+// display the token amounts of the message in a human-readable form.
+func (w *wallet) SignTypedDataWithTokens(account accounts.Account, typedData apitypes.TypedData, tokens []accounts.TokenInfo) ([]byte, error) {
+	_, rawData, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	rawDataBz := []byte(rawData)
+
+	sigBytes, err := w.signWithDevice(account, func(path gethaccounts.DerivationPath) ([]byte, error) {
+		for _, token := range tokens {
+			if err := w.driver.ProvideTokenInfo(token); err != nil {
+				return nil, fmt.Errorf("could not provide %s token information: %w", token.Ticker, err)
+			}
+		}
+		return w.driver.SignTypedMessage(path, rawDataBz[2:34], rawDataBz[34:66])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify recovered public key matches expected value
+	if err = w.verifySignature(account, rawDataBz, sigBytes); err != nil {
+		return nil, err
+	}
+
+	return sigBytes, nil
+}
+
+// SignTypedDataFull behaves like SignTypedDataWithTokens, but streams the whole
+// typed data to the device instead of its hashes, so that it displays the fields
+// of the message rather than the hashes only.
+func (w *wallet) SignTypedDataFull(account accounts.Account, typedData apitypes.TypedData, tokens []accounts.TokenInfo) ([]byte, error) {
+	_, rawData, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	rawDataBz := []byte(rawData)
+
+	sigBytes, err := w.signWithDevice(account, func(path gethaccounts.DerivationPath) ([]byte, error) {
+		for _, token := range tokens {
+			if err := w.driver.ProvideTokenInfo(token); err != nil {
+				return nil, fmt.Errorf("could not provide %s token information: %w", token.Ticker, err)
+			}
+		}
+		return w.driver.SignTypedMessageFull(path, typedData)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify recovered public key matches expected value
+	if err = w.verifySignature(account, rawDataBz, sigBytes); err != nil {
+		return nil, err
+	}
+
+	return sigBytes, nil
+}
+
+// SignTx implements accounts.Wallet. It sends the transaction over to the Ledger
+// wallet to request a confirmation from the user. It returns either the signed
+// transaction or a failure if the user denied the transaction.
+//
+// Note, if the version of the Ethereum application running on the Ledger wallet is
+// too old to sign EIP-155 transactions, but such is requested nonetheless, an error
+// will be returned opposed to silently signing in Homestead mode.
+func (w *wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	var (
+		sender common.Address
+		signed *types.Transaction
+	)
+	_, err := w.signWithDevice(account, func(path gethaccounts.DerivationPath) (_ []byte, err error) {
+		sender, signed, err = w.driver.SignTx(path, tx, chainID)
+		return nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the sender to avoid hardware fault surprises
+	if sender != account.Address {
+		return nil, fmt.Errorf("signer mismatch: expected %s, got %s", account.Address.Hex(), sender.Hex())
+	}
+
+	return signed, nil
+}
+
+// SignText signs the given text following the EIP-191 personal_sign scheme, i.e.
+// keccak256("\x19Ethereum Signed Message:\n" + len(text) + text). The returned
+// signature is in the 65-byte [R || S || V] format, where V is 27 or 28.
+func (w *wallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	sigBytes, err := w.signWithDevice(account, func(path gethaccounts.DerivationPath) ([]byte, error) {
+		return w.driver.SignPersonalMessage(path, text)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify recovered public key matches expected value
+	_, rawData := gethaccounts.TextAndHash(text)
+	if err = w.verifySignature(account, []byte(rawData), sigBytes); err != nil {
 		return nil, err
 	}
 